@@ -0,0 +1,144 @@
+package kuzu
+
+// #include "kuzu.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/cdata"
+)
+
+// ToArrowRecordReader returns a reader over the remaining tuples of the
+// QueryResult as Arrow record batches of at most chunkSize rows each.
+// The record batches are imported through the Arrow C Data Interface, so the
+// column buffers produced by Kuzu are not copied.
+// The caller must call Release on the reader when it is no longer needed.
+// A record batch returned by the reader is only valid until the next call to
+// Next; call Retain on it to keep it for longer.
+func (queryResult *QueryResult) ToArrowRecordReader(chunkSize int64) (array.RecordReader, error) {
+	if chunkSize <= 0 {
+		return nil, newError(ErrorKindInvalidArgument, "chunk size must be positive, got %d", chunkSize)
+	}
+	cSchema := (*C.struct_ArrowSchema)(C.calloc(1, C.sizeof_struct_ArrowSchema))
+	defer C.free(unsafe.Pointer(cSchema))
+	queryResult.mu.Lock()
+	if queryResult.isClosed {
+		queryResult.mu.Unlock()
+		return nil, closedError("query result")
+	}
+	status := C.kuzu_query_result_get_arrow_schema(&queryResult.cQueryResult, cSchema)
+	queryResult.mu.Unlock()
+	if status != C.KuzuSuccess {
+		return nil, newError(ErrorKindRuntime, "failed to get arrow schema with status %d", status)
+	}
+	// ImportCArrowSchema releases the C schema whether or not it succeeds.
+	schema, err := cdata.ImportCArrowSchema((*cdata.CArrowSchema)(unsafe.Pointer(cSchema)))
+	if err != nil {
+		return nil, newError(ErrorKindConversion, "failed to import arrow schema: %w", err)
+	}
+	return &arrowRecordReader{
+		refCount:    1,
+		queryResult: queryResult,
+		schema:      schema,
+		chunkSize:   chunkSize,
+	}, nil
+}
+
+// arrowRecordReader implements array.RecordReader on top of a QueryResult.
+type arrowRecordReader struct {
+	refCount    int64
+	queryResult *QueryResult
+	schema      *arrow.Schema
+	chunkSize   int64
+	current     arrow.RecordBatch
+	err         error
+}
+
+// Retain increases the reference count of the reader.
+func (reader *arrowRecordReader) Retain() {
+	atomic.AddInt64(&reader.refCount, 1)
+}
+
+// Release decreases the reference count of the reader. The current record
+// batch is released when the count reaches zero.
+func (reader *arrowRecordReader) Release() {
+	if atomic.AddInt64(&reader.refCount, -1) == 0 {
+		if reader.current != nil {
+			reader.current.Release()
+			reader.current = nil
+		}
+		reader.queryResult = nil
+	}
+}
+
+// Schema returns the Arrow schema of the query result.
+func (reader *arrowRecordReader) Schema() *arrow.Schema {
+	return reader.schema
+}
+
+// Next advances the reader to the next record batch. It returns false when
+// the query result is exhausted or an error occurred, which is reported by Err.
+func (reader *arrowRecordReader) Next() bool {
+	if reader.current != nil {
+		reader.current.Release()
+		reader.current = nil
+	}
+	if reader.err != nil || reader.queryResult == nil {
+		return false
+	}
+	cArray := (*C.struct_ArrowArray)(C.calloc(1, C.sizeof_struct_ArrowArray))
+	defer C.free(unsafe.Pointer(cArray))
+	hasNext, err := reader.queryResult.nextArrowChunk(reader.chunkSize, cArray)
+	if err != nil || !hasNext {
+		reader.err = err
+		return false
+	}
+	record, err := cdata.ImportCRecordBatchWithSchema((*cdata.CArrowArray)(unsafe.Pointer(cArray)), reader.schema)
+	if err != nil {
+		cdata.ReleaseCArrowArray((*cdata.CArrowArray)(unsafe.Pointer(cArray)))
+		reader.err = newError(ErrorKindConversion, "failed to import arrow chunk: %w", err)
+		return false
+	}
+	reader.current = record
+	return true
+}
+
+// RecordBatch returns the current record batch.
+func (reader *arrowRecordReader) RecordBatch() arrow.RecordBatch {
+	return reader.current
+}
+
+// Record returns the current record batch.
+//
+// Deprecated: Use RecordBatch instead.
+func (reader *arrowRecordReader) Record() arrow.Record {
+	return reader.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (reader *arrowRecordReader) Err() error {
+	return reader.err
+}
+
+// nextArrowChunk exports the next chunk of at most chunkSize tuples of the
+// QueryResult into cArray. It returns false if the QueryResult is exhausted.
+func (queryResult *QueryResult) nextArrowChunk(chunkSize int64, cArray *C.struct_ArrowArray) (bool, error) {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.isClosed {
+		return false, closedError("query result")
+	}
+	if !C.kuzu_query_result_has_next(&queryResult.cQueryResult) {
+		return false, nil
+	}
+	status := C.kuzu_query_result_get_next_arrow_chunk(&queryResult.cQueryResult, C.int64_t(chunkSize), cArray)
+	if status != C.KuzuSuccess {
+		return false, newError(ErrorKindRuntime, "failed to get next arrow chunk with status %d", status)
+	}
+	return true, nil
+}
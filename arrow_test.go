@@ -0,0 +1,74 @@
+package kuzu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryResultToArrowRecordReader(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) RETURN a.ID, a.fName ORDER BY a.ID;")
+	assert.Nil(t, err)
+	defer res.Close()
+	reader, err := res.ToArrowRecordReader(3)
+	assert.Nil(t, err)
+	defer reader.Release()
+	schema := reader.Schema()
+	assert.Equal(t, 2, schema.NumFields())
+	assert.Equal(t, "a.ID", schema.Field(0).Name)
+	assert.Equal(t, arrow.PrimitiveTypes.Int64, schema.Field(0).Type)
+	assert.Equal(t, "a.fName", schema.Field(1).Name)
+	var ids []int64
+	var names []string
+	numRecords := 0
+	for reader.Next() {
+		record := reader.RecordBatch()
+		assert.LessOrEqual(t, record.NumRows(), int64(3))
+		idColumn := record.Column(0).(*array.Int64)
+		nameColumn := record.Column(1).(*array.String)
+		for i := 0; i < int(record.NumRows()); i++ {
+			ids = append(ids, idColumn.Value(i))
+			// String values point into the record batch, which is released
+			// by the next call to Next.
+			names = append(names, strings.Clone(nameColumn.Value(i)))
+		}
+		numRecords++
+	}
+	assert.Nil(t, reader.Err())
+	assert.Equal(t, 3, numRecords)
+	assert.Equal(t, []int64{0, 2, 3, 5, 7, 8, 9, 10}, ids)
+	assert.Equal(t, "Alice", names[0])
+	assert.False(t, reader.Next())
+}
+
+func TestQueryResultToArrowRecordReaderRetain(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) RETURN a.ID ORDER BY a.ID;")
+	assert.Nil(t, err)
+	defer res.Close()
+	reader, err := res.ToArrowRecordReader(100)
+	assert.Nil(t, err)
+	assert.True(t, reader.Next())
+	record := reader.RecordBatch()
+	record.Retain()
+	reader.Release()
+	// The retained record batch outlives the reader and the query result.
+	res.Close()
+	assert.Equal(t, int64(8), record.NumRows())
+	assert.Equal(t, int64(0), record.Column(0).(*array.Int64).Value(0))
+	record.Release()
+}
+
+func TestQueryResultToArrowRecordReaderInvalidChunkSize(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("RETURN 1;")
+	assert.Nil(t, err)
+	defer res.Close()
+	_, err = res.ToArrowRecordReader(0)
+	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
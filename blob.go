@@ -0,0 +1,106 @@
+package kuzu
+
+// #include "kuzu.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// blobHexDigits are the digits used to escape the bytes of a BLOB, as in the
+// string representation of a BLOB produced by Kuzu.
+const blobHexDigits = "0123456789ABCDEF"
+
+// kuzuBlobValueToGoValue converts a kuzu_value of type BLOB to a byte slice.
+// The C API returns BLOBs as null-terminated buffers without their length,
+// so the bytes are decoded from the string representation of the value
+// instead, in which Kuzu escapes every byte that is not printable.
+func kuzuBlobValueToGoValue(kuzuValue C.kuzu_value) ([]byte, error) {
+	cString := C.kuzu_value_to_string(&kuzuValue)
+	defer C.kuzu_destroy_string(cString)
+	return decodeBlobString(C.GoString(cString))
+}
+
+// encodeBlobString returns the string representation of the bytes of a BLOB,
+// in which the bytes that are not printable ASCII characters and the
+// backslashes are escaped as \xHH. Kuzu converts it back to the original
+// bytes with the BLOB function.
+func encodeBlobString(blob []byte) string {
+	var builder strings.Builder
+	builder.Grow(len(blob))
+	for _, b := range blob {
+		if b < 0x20 || b > 0x7E || b == '\\' {
+			builder.WriteString(`\x`)
+			builder.WriteByte(blobHexDigits[b>>4])
+			builder.WriteByte(blobHexDigits[b&0x0F])
+		} else {
+			builder.WriteByte(b)
+		}
+	}
+	return builder.String()
+}
+
+// errBlobNul is returned when a BLOB holding NUL bytes is converted to a
+// kuzu_value other than as the value of a parameter.
+var errBlobNul = errors.New("a BLOB holding NUL bytes can only be passed as the value of a parameter")
+
+// goBlobToKuzuValue converts a byte slice to a kuzu_value of type BLOB. The C
+// API cannot create BLOB values, so the bytes are copied into a NULL BLOB from
+// a STRING value, which shares the physical type of BLOBs. A STRING ends at
+// the first NUL byte, so bytes holding one are converted with the BLOB
+// function by the connection instead.
+func goBlobToKuzuValue(blob []byte) (*C.kuzu_value, error) {
+	if bytes.IndexByte(blob, 0) >= 0 {
+		return nil, &queriedValueError{query: "RETURN BLOB($value)", value: encodeBlobString(blob), err: errBlobNul}
+	}
+	cString := C.CString(string(blob))
+	defer C.free(unsafe.Pointer(cString))
+	stringValue := C.kuzu_value_create_string(cString)
+	defer C.kuzu_value_destroy(stringValue)
+	var blobType C.kuzu_logical_type
+	C.kuzu_data_type_create(C.KUZU_BLOB, nil, 0, &blobType)
+	defer C.kuzu_data_type_destroy(&blobType)
+	kuzuValue := C.kuzu_value_create_null_with_data_type(&blobType)
+	C.kuzu_value_copy(kuzuValue, stringValue)
+	return kuzuValue, nil
+}
+
+// decodeBlobString returns the bytes of a BLOB from its string representation.
+func decodeBlobString(s string) ([]byte, error) {
+	blob := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			blob = append(blob, s[i])
+			continue
+		}
+		if i+3 >= len(s) || s[i+1] != 'x' {
+			return nil, fmt.Errorf("invalid escape sequence at offset %d in BLOB", i)
+		}
+		high, highOk := hexDigitValue(s[i+2])
+		low, lowOk := hexDigitValue(s[i+3])
+		if !highOk || !lowOk {
+			return nil, fmt.Errorf("invalid escape sequence at offset %d in BLOB", i)
+		}
+		blob = append(blob, high<<4|low)
+		i += 3
+	}
+	return blob, nil
+}
+
+// hexDigitValue returns the value of a hexadecimal digit.
+func hexDigitValue(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,200 @@
+package kuzu
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Marshaler is implemented by types that can convert themselves to a value
+// that can be passed as a parameter, such as a decimal.Decimal or a
+// map[string]any for a STRUCT.
+type Marshaler interface {
+	MarshalKuzu() (any, error)
+}
+
+// Unmarshaler is implemented by types that can be scanned from a Kuzu value.
+// UnmarshalKuzu receives the value as returned by FlatTuple.GetValue, which is
+// nil for NULL.
+type Unmarshaler interface {
+	UnmarshalKuzu(value any) error
+}
+
+// encoders holds the functions registered with RegisterEncoder, keyed by the
+// reflect.Type of the Go values they convert.
+var encoders sync.Map
+
+// decoders holds the functions registered with RegisterDecoder, keyed by the
+// reflect.Type of the Go values they produce.
+var decoders sync.Map
+
+// typeDecoders holds the functions registered with RegisterTypeDecoder, keyed
+// by the TypeID of the Kuzu values they convert.
+var typeDecoders sync.Map
+
+// RegisterEncoder registers a function converting Go values of type T to
+// values that can be passed as parameters, e.g. a Money type to a
+// decimal.Decimal bound as a DECIMAL. The encoder takes precedence over the
+// built-in conversions and the interfaces implemented by T. Registering
+// another encoder for T replaces it.
+func RegisterEncoder[T any](encode func(value T) (any, error)) {
+	encoders.Store(reflect.TypeFor[T](), func(value any) (any, error) {
+		return encode(value.(T))
+	})
+}
+
+// RegisterDecoder registers a function converting Kuzu values to Go values of
+// type T, used when a value is scanned into a T. The function receives the
+// value as returned by FlatTuple.GetValue, which is never nil: scanning NULL
+// into a T fails unless T is a pointer, an interface, a map or a slice.
+// Registering another decoder for T replaces it.
+func RegisterDecoder[T any](decode func(value any) (T, error)) {
+	decoders.Store(reflect.TypeFor[T](), func(value any) (any, error) {
+		return decode(value)
+	})
+}
+
+// RegisterTypeDecoder registers a function converting the values of the Kuzu
+// type to the Go values returned by FlatTuple.GetValue, instead of the default
+// ones. The function receives the default Go value, which is never nil. It
+// applies to all the values of the type, including the ones nested in lists,
+// structs, maps, nodes and relationships. Registering another decoder for the
+// type replaces it.
+func RegisterTypeDecoder(id TypeID, decode func(value any) (any, error)) {
+	typeDecoders.Store(id, decode)
+}
+
+// encodeValue converts a Go value to a value supported by goValueToKuzuValue
+// with the encoder registered for its type or the Marshaler interface. It
+// returns false if neither applies.
+func encodeValue(value any) (any, bool, error) {
+	if encode, ok := encoders.Load(reflect.TypeOf(value)); ok {
+		encoded, err := encode.(func(any) (any, error))(value)
+		return encoded, true, err
+	}
+	if marshaler, ok := value.(Marshaler); ok {
+		encoded, err := marshaler.MarshalKuzu()
+		return encoded, true, err
+	}
+	return nil, false, nil
+}
+
+// encodeFallbackValue converts a Go value that has no built-in conversion with
+// the driver.Valuer or encoding.TextMarshaler interfaces. It returns false if
+// neither applies.
+func encodeFallbackValue(value any) (any, bool, error) {
+	switch v := value.(type) {
+	case driver.Valuer:
+		encoded, err := v.Value()
+		return encoded, true, err
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		return string(text), true, err
+	}
+	return nil, false, nil
+}
+
+// decodeTypeValue converts the default Go value of a Kuzu value of the type
+// with the decoder registered for the type, if any.
+func decodeTypeValue(id TypeID, value any) (any, error) {
+	decode, ok := typeDecoders.Load(id)
+	if !ok || value == nil {
+		return value, nil
+	}
+	return decode.(func(any) (any, error))(value)
+}
+
+// decodeInto stores a value in dest with the decoder registered for the type
+// of dest or, unless the value can be assigned to dest as is, one of the
+// Unmarshaler, sql.Scanner and encoding.TextUnmarshaler interfaces implemented
+// by a pointer to dest. It returns false if none applies.
+func decodeInto(dest reflect.Value, value any) (bool, error) {
+	if decode, ok := decoders.Load(dest.Type()); ok && value != nil {
+		decoded, err := decode.(func(any) (any, error))(value)
+		if err != nil {
+			return true, err
+		}
+		dest.Set(reflect.ValueOf(decoded))
+		return true, nil
+	}
+	if value != nil && reflect.TypeOf(value).AssignableTo(dest.Type()) {
+		return false, nil
+	}
+	if !dest.CanAddr() {
+		return false, nil
+	}
+	switch d := dest.Addr().Interface().(type) {
+	case Unmarshaler:
+		return true, d.UnmarshalKuzu(value)
+	case sql.Scanner:
+		return true, d.Scan(toDriverValue(value))
+	case encoding.TextUnmarshaler:
+		if value == nil {
+			return false, nil
+		}
+		if text, ok := toText(value); ok {
+			return true, d.UnmarshalText([]byte(text))
+		}
+	}
+	return false, nil
+}
+
+// toDriverValue converts a Go value returned by kuzuValueToGoValue to one of
+// the types expected by sql.Scanner implementations when possible: int64,
+// float64, bool, []byte, string, time.Time or nil.
+func toDriverValue(value any) any {
+	switch v := value.(type) {
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		if v <= 1<<63-1 {
+			return int64(v)
+		}
+	case float32:
+		return float64(v)
+	case *big.Int:
+		if v.IsInt64() {
+			return v.Int64()
+		}
+		return v.String()
+	case uuid.UUID:
+		return v.String()
+	case decimal.Decimal:
+		return v.String()
+	}
+	return value
+}
+
+// toText returns the text representation of a Go value returned by
+// kuzuValueToGoValue, for encoding.TextUnmarshaler implementations.
+func toText(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		return string(text), err == nil
+	case fmt.Stringer:
+		return v.String(), true
+	}
+	return "", false
+}
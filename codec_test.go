@@ -0,0 +1,180 @@
+package kuzu
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// money is an amount in cents, stored in Kuzu as a DECIMAL.
+type money int64
+
+// geoPoint is stored in Kuzu as a STRUCT(lat DOUBLE, lon DOUBLE).
+type geoPoint struct {
+	lat, lon float64
+}
+
+func (point geoPoint) MarshalKuzu() (any, error) {
+	return map[string]any{"lat": point.lat, "lon": point.lon}, nil
+}
+
+func (point *geoPoint) UnmarshalKuzu(value any) error {
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal %T into geoPoint", value)
+	}
+	point.lat, _ = fields["lat"].(float64)
+	point.lon, _ = fields["lon"].(float64)
+	return nil
+}
+
+func init() {
+	RegisterEncoder(func(value money) (any, error) {
+		return decimal.New(int64(value), -2), nil
+	})
+	RegisterDecoder(func(value any) (money, error) {
+		amount, ok := value.(decimal.Decimal)
+		if !ok {
+			return 0, fmt.Errorf("cannot decode %T into money", value)
+		}
+		return money(amount.Shift(2).IntPart()), nil
+	})
+}
+
+func TestEncoderAndDecoder(t *testing.T) {
+	db, err := OpenInMemoryDatabase(DefaultSystemConfig())
+	assert.Nil(t, err)
+	defer db.Close()
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	defer conn.Close()
+	res, err := conn.Query("CREATE NODE TABLE account(id INT64, balance DECIMAL(10, 2), PRIMARY KEY(id));")
+	assert.Nil(t, err)
+	res.Close()
+	create, err := conn.Prepare("CREATE (a:account {id: 1, balance: $balance}) RETURN a.balance")
+	assert.Nil(t, err)
+	res, err = conn.Execute(create, map[string]any{"balance": money(123456)})
+	assert.Nil(t, err)
+	next, err := res.Next()
+	assert.Nil(t, err)
+	var balance money
+	assert.Nil(t, next.Scan(&balance))
+	assert.Equal(t, money(123456), balance)
+	var optional *money
+	assert.Nil(t, next.Scan(&optional))
+	assert.Equal(t, money(123456), *optional)
+	res.Close()
+	// The decoder errors are returned by Scan.
+	res, err = conn.Query("RETURN 'not money'")
+	assert.Nil(t, err)
+	next, _ = res.Next()
+	assert.NotNil(t, next.Scan(&balance))
+	res.Close()
+}
+
+func TestMarshaler(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	preparedStatement, err := conn.Prepare("RETURN $1, $1.lat")
+	assert.Nil(t, err)
+	res, err := conn.Execute(preparedStatement, map[string]any{"1": geoPoint{lat: 48.85, lon: 2.35}})
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	var point geoPoint
+	var lat float64
+	assert.Nil(t, next.Scan(&point, &lat))
+	assert.Equal(t, geoPoint{lat: 48.85, lon: 2.35}, point)
+	assert.Equal(t, 48.85, lat)
+	res.Close()
+}
+
+func TestScannerAndValuer(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	preparedStatement, err := conn.Prepare("RETURN $1, $2, CAST(NULL AS INT32), CAST(7 AS INT16)")
+	assert.Nil(t, err)
+	res, err := conn.Execute(preparedStatement, map[string]any{
+		"1": sql.NullString{String: "Alice", Valid: true},
+		"2": sql.NullString{},
+	})
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	var name, missing sql.NullString
+	var nullInt, smallInt sql.NullInt64
+	assert.Nil(t, next.Scan(&name, &missing, &nullInt, &smallInt))
+	assert.Equal(t, sql.NullString{String: "Alice", Valid: true}, name)
+	assert.False(t, missing.Valid)
+	assert.False(t, nullInt.Valid)
+	assert.Equal(t, sql.NullInt64{Int64: 7, Valid: true}, smallInt)
+	res.Close()
+}
+
+func TestTextMarshaler(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	preparedStatement, err := conn.Prepare("RETURN $1")
+	assert.Nil(t, err)
+	addr := netip.MustParseAddr("192.168.0.1")
+	res, err := conn.Execute(preparedStatement, map[string]any{"1": addr})
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	value, _ := next.GetValue(0)
+	assert.Equal(t, "192.168.0.1", value)
+	var scanned netip.Addr
+	assert.Nil(t, next.Scan(&scanned))
+	assert.Equal(t, addr, scanned)
+	res.Close()
+}
+
+func TestTypeDecoder(t *testing.T) {
+	RegisterTypeDecoder(TypeUUID, func(value any) (any, error) {
+		return strings.ToUpper(value.(uuid.UUID).String()), nil
+	})
+	t.Cleanup(func() { typeDecoders.Delete(TypeUUID) })
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) WHERE a.ID = 0 RETURN a.u, [a.u], a")
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	values, err := next.GetAsSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, "A0EEBC99-9C0B-4EF8-BB6D-6BB9BD380A11", values[0])
+	assert.Equal(t, []any{"A0EEBC99-9C0B-4EF8-BB6D-6BB9BD380A11"}, values[1])
+	assert.Equal(t, "A0EEBC99-9C0B-4EF8-BB6D-6BB9BD380A11", values[2].(Node).Properties["u"])
+	res.Close()
+	RegisterTypeDecoder(TypeUUID, func(value any) (any, error) {
+		return nil, errors.New("invalid")
+	})
+	res, err = conn.Query("MATCH (a:person) WHERE a.ID = 0 RETURN a.u")
+	assert.Nil(t, err)
+	next, _ = res.Next()
+	_, err = next.GetValue(0)
+	assert.True(t, errors.Is(err, ErrConversion))
+	res.Close()
+}
+
+func TestDriverEncoder(t *testing.T) {
+	dbPath := strings.ReplaceAll(getDatabasePath(t), "\\", "/")
+	db, err := sql.Open(Name, fmt.Sprintf("kuzu://%s", dbPath))
+	assert.Nil(t, err)
+	defer os.RemoveAll(dbPath)
+	defer db.Close()
+	type record struct {
+		Name string
+	}
+	row := db.QueryRow("RETURN $amount, $point.lon, $record.Name, typeof($missing)",
+		sql.Named("amount", money(250)), sql.Named("point", geoPoint{lat: 1, lon: 2}),
+		sql.Named("record", record{Name: "Alice"}), sql.Named("missing", (*int32)(nil)))
+	var name, missingType string
+	var amount any
+	var lon float64
+	assert.Nil(t, row.Scan(&amount, &lon, &name, &missingType))
+	assert.Equal(t, decimal.RequireFromString("2.50"), amount)
+	assert.Equal(t, 2.0, lon)
+	assert.Equal(t, "Alice", name)
+	assert.Equal(t, "INT32", missingType)
+}
@@ -5,20 +5,45 @@ package kuzu
 import "C"
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"runtime"
+	"sync"
+	"time"
 	"unsafe"
 )
 
 // Connection represents a connection to a Kuzu database.
+// A Connection is safe for concurrent use by multiple goroutines. The
+// statements issued concurrently on the same Connection are serialized: each
+// of them waits until the previous one has completed. Use one Connection per
+// goroutine to execute queries in parallel.
 type Connection struct {
 	cConnection C.kuzu_connection
 	database    *Database
 	isClosed    bool
+	timeout     uint64
+	transaction *Transaction
+	results     children[QueryResult]
+	statements  children[PreparedStatement]
+	// valueOptions are the options of the query results of the connection.
+	valueOptions ValueOptions
+	// mu serializes the statements executed on the connection and guards its
+	// state.
+	mu sync.Mutex
+	// handleMu guards the C connection against Close for Interrupt, which must
+	// not wait for the running statement.
+	handleMu sync.RWMutex
 }
 
 // OpenConnection opens a connection to the specified database.
+// It returns an error matching ErrClosed if the database is closed.
 func OpenConnection(database *Database) (*Connection, error) {
+	database.mu.RLock()
+	defer database.mu.RUnlock()
+	if database.isClosed {
+		return nil, closedError("database")
+	}
 	conn := &Connection{}
 	conn.database = database
 	runtime.SetFinalizer(conn, func(conn *Connection) {
@@ -26,84 +51,283 @@ func OpenConnection(database *Database) (*Connection, error) {
 	})
 	status := C.kuzu_connection_init(&database.cDatabase, &conn.cConnection)
 	if status != C.KuzuSuccess {
-		return conn, fmt.Errorf("failed to open connection with status %d", status)
+		return conn, newError(ErrorKindConnection, "failed to open connection with status %d", status)
 	}
+	database.connections.add(conn)
 	return conn, nil
 }
 
 // Close closes the Connection. Calling this method is optional.
 // The Connection will be closed automatically when it is garbage collected.
+// Close waits for the running statement, if any, to complete. The query
+// results and prepared statements of the Connection that are still open are
+// closed first. It is safe to call Close multiple times and concurrently; the
+// statements issued after the Connection is closed fail with an error
+// matching ErrClosed.
 func (conn *Connection) Close() {
+	for _, onClose := range conn.close() {
+		onClose()
+	}
+}
+
+// close closes the Connection and returns the onClose functions of the query
+// results it closed, which must be run once the statement lock is released.
+func (conn *Connection) close() []func() {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
 	if conn.isClosed {
-		return
+		return nil
+	}
+	for _, preparedStatement := range conn.statements.drain() {
+		preparedStatement.Close()
+	}
+	var onCloses []func()
+	for _, queryResult := range conn.results.drain() {
+		if onClose := queryResult.close(); onClose != nil {
+			onCloses = append(onCloses, onClose)
+		}
+	}
+	conn.handleMu.Lock()
+	defer conn.handleMu.Unlock()
+	conn.database.connections.remove(conn)
+	// The database is only closed before the connection if the connection was
+	// already unreachable, in which case its C handle is gone with the database.
+	conn.database.handleMu.RLock()
+	defer conn.database.handleMu.RUnlock()
+	if !conn.database.isClosed {
+		C.kuzu_connection_destroy(&conn.cConnection)
 	}
-	C.kuzu_connection_destroy(&conn.cConnection)
 	conn.isClosed = true
+	return onCloses
+}
+
+// closed returns true if the connection is closed.
+func (conn *Connection) closed() bool {
+	conn.handleMu.RLock()
+	defer conn.handleMu.RUnlock()
+	return conn.isClosed
+}
+
+// lock acquires the statement lock of the connection. If the connection is
+// closed, it returns an error matching ErrClosed without holding the lock.
+func (conn *Connection) lock() error {
+	conn.mu.Lock()
+	if conn.isClosed {
+		conn.mu.Unlock()
+		return closedError("connection")
+	}
+	return nil
 }
 
 // GetMaxNumThreads returns the maximum number of threads that can be used for
-// executing a query in parallel.
+// executing a query in parallel. It returns 0 if the connection is closed.
 func (conn *Connection) GetMaxNumThreads() uint64 {
+	if err := conn.lock(); err != nil {
+		return 0
+	}
+	defer conn.mu.Unlock()
 	numThreads := C.uint64_t(0)
 	C.kuzu_connection_get_max_num_thread_for_exec(&conn.cConnection, &numThreads)
 	return uint64(numThreads)
 }
 
 // SetMaxNumThreads sets the maximum number of threads that can be used for
-// executing a query in parallel.
+// executing a query in parallel. It has no effect if the connection is closed.
 func (conn *Connection) SetMaxNumThreads(numThreads uint64) {
+	if err := conn.lock(); err != nil {
+		return
+	}
+	defer conn.mu.Unlock()
 	C.kuzu_connection_set_max_num_thread_for_exec(&conn.cConnection, C.uint64_t(numThreads))
 }
 
+// ValueOptions controls how the values of query results are converted to Go
+// values by FlatTuple.GetValue and the methods built on it, and how the Go
+// values of parameters are converted to Kuzu values. The zero value selects
+// the default conversions.
+type ValueOptions struct {
+	// GoMaps decodes MAP values to Go maps of the types of their keys and
+	// values, e.g. a map[int64]string for a MAP(INT64, STRING), instead of a
+	// []MapItem. A MAP is still decoded to a []MapItem if it is empty, if its
+	// keys cannot be compared by value in Go, e.g. INT128 or DECIMAL keys, or
+	// if its values are NULLs that the Go type of the other values cannot hold.
+	GoMaps bool
+	// Location is the location of the time.Time values returned for the
+	// TIMESTAMP types, e.g. time.UTC. A DATE is returned as the midnight of
+	// its day in the location. If nil, timestamps are returned in the local
+	// time zone and dates in UTC.
+	Location *time.Location
+	// TimestampTZInUTC returns TIMESTAMP_TZ values in UTC, the offset Kuzu
+	// stores and prints them with, instead of in Location. Kuzu stores a
+	// TIMESTAMP_TZ as an instant, so the offset of the time it was created
+	// from is not preserved either way.
+	TimestampTZInUTC bool
+	// TimestampType is the type that time.Time parameters are bound as:
+	// TypeTimestamp, TypeTimestampNs, TypeTimestampMs, TypeTimestampSec or
+	// TypeTimestampTz. The time is truncated to the precision of the type. If
+	// zero, a time.Time is bound as a TIMESTAMP_NS if it has a fraction of a
+	// microsecond and as a TIMESTAMP otherwise. The Date and Timestamp wrapper
+	// types are always bound as their own type.
+	TimestampType TypeID
+	// IntervalAsDuration returns INTERVAL values as time.Duration values,
+	// converted as by Interval.Duration, instead of Interval values. It lets
+	// database/sql scan INTERVAL values into a time.Duration.
+	IntervalAsDuration bool
+	// nullTypes are the types whose typed NULLs goNullToKuzuValue is
+	// resolving, so that a type that refers to itself ends the recursion.
+	nullTypes *typeList
+}
+
+// timestamp returns a time.Time returned for a TIMESTAMP value of the type in
+// the location selected by the options.
+func (options ValueOptions) timestamp(t time.Time, id TypeID) time.Time {
+	if id == TypeTimestampTz && options.TimestampTZInUTC {
+		return t.UTC()
+	}
+	if options.Location != nil {
+		return t.In(options.Location)
+	}
+	return t
+}
+
+// date returns the time.Time returned for a DATE value, at midnight of the
+// date in the location selected by the options.
+func (options ValueOptions) date(t time.Time) time.Time {
+	if options.Location != nil {
+		year, month, day := t.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, options.Location)
+	}
+	return t
+}
+
+// GetValueOptions returns the options used to convert the values of the query
+// results and the parameters of the connection. It returns the zero value if
+// the connection is closed.
+func (conn *Connection) GetValueOptions() ValueOptions {
+	if err := conn.lock(); err != nil {
+		return ValueOptions{}
+	}
+	defer conn.mu.Unlock()
+	return conn.valueOptions
+}
+
+// SetValueOptions sets the options used to convert the values of the query
+// results and the parameters of the connection. The options apply to the
+// statements executed after the call. It has no effect if the connection is
+// closed.
+func (conn *Connection) SetValueOptions(options ValueOptions) {
+	if err := conn.lock(); err != nil {
+		return
+	}
+	defer conn.mu.Unlock()
+	conn.valueOptions = options
+}
+
 // Interrupt interrupts the execution of the current query on the connection.
+// Unlike the other methods of Connection, it does not wait for the running
+// statement, so it can be called from another goroutine to stop it.
 func (conn *Connection) Interrupt() {
+	conn.handleMu.RLock()
+	defer conn.handleMu.RUnlock()
+	if conn.isClosed {
+		return
+	}
 	C.kuzu_connection_interrupt(&conn.cConnection)
 }
 
 // SetTimeout sets the timeout for the queries executed on the connection.
 // The timeout is specified in milliseconds. A value of 0 means no timeout.
 // If a query takes longer than the specified timeout, it will be interrupted.
+// It has no effect if the connection is closed.
 func (conn *Connection) SetTimeout(timeout uint64) {
+	if err := conn.lock(); err != nil {
+		return
+	}
+	defer conn.mu.Unlock()
+	conn.setTimeout(timeout)
+}
+
+// setTimeout sets the query timeout of the connection. The caller must hold
+// the statement lock.
+func (conn *Connection) setTimeout(timeout uint64) {
 	C.kuzu_connection_set_query_timeout(&conn.cConnection, C.uint64_t(timeout))
+	conn.timeout = timeout
 }
 
 // Query executes the specified query string and returns the result.
 func (conn *Connection) Query(query string) (*QueryResult, error) {
+	if err := conn.lock(); err != nil {
+		return nil, err
+	}
+	defer conn.mu.Unlock()
+	return conn.query(query)
+}
+
+// query executes the specified query string. The caller must hold the
+// statement lock.
+func (conn *Connection) query(query string) (*QueryResult, error) {
 	cQuery := C.CString(query)
 	defer C.free(unsafe.Pointer(cQuery))
 	queryResult := &QueryResult{}
 	queryResult.connection = conn
+	queryResult.valueOptions = conn.valueOptions
 	runtime.SetFinalizer(queryResult, func(queryResult *QueryResult) {
 		queryResult.Close()
 	})
+	conn.results.add(queryResult)
+	start := time.Now()
 	status := C.kuzu_connection_query(&conn.cConnection, cQuery, &queryResult.cQueryResult)
 	if status != C.KuzuSuccess || !C.kuzu_query_result_is_success(&queryResult.cQueryResult) {
 		cErrMsg := C.kuzu_query_result_get_error_message(&queryResult.cQueryResult)
 		defer C.kuzu_destroy_string(cErrMsg)
-		return queryResult, fmt.Errorf(C.GoString(cErrMsg))
+		return queryResult, conn.queryError(C.GoString(cErrMsg), query, start)
 	}
 	return queryResult, nil
 }
 
 // Execute executes the specified prepared statement with the specified arguments and returns the result.
-// The arguments are a map of parameter names to values.
+// The arguments are a map of parameter names to values. A []byte is passed as
+// a BLOB, and so is the content of an io.Reader, which is read to the end into
+// memory first. A BLOB holding NUL bytes, a Decimal and a decimal.Decimal can
+// only be passed as the value of a parameter: binding one nested in a LIST,
+// MAP or STRUCT fails with an error matching ErrConversion.
 func (conn *Connection) Execute(preparedStatement *PreparedStatement, args map[string]any) (*QueryResult, error) {
+	if err := conn.lock(); err != nil {
+		return nil, err
+	}
+	defer conn.mu.Unlock()
+	return conn.execute(preparedStatement, args)
+}
+
+// execute executes the specified prepared statement with the specified
+// arguments. The caller must hold the statement lock.
+func (conn *Connection) execute(preparedStatement *PreparedStatement, args map[string]any) (*QueryResult, error) {
+	preparedStatement.mu.Lock()
+	defer preparedStatement.mu.Unlock()
+	if preparedStatement.isClosed {
+		return nil, closedError("prepared statement")
+	}
 	queryResult := &QueryResult{}
 	queryResult.connection = conn
+	queryResult.valueOptions = conn.valueOptions
 	for key, value := range args {
 		err := conn.bindParameter(preparedStatement, key, value)
 		if err != nil {
+			// The query result has no C handle to destroy.
+			queryResult.isClosed = true
 			return queryResult, err
 		}
 	}
 	runtime.SetFinalizer(queryResult, func(queryResult *QueryResult) {
 		queryResult.Close()
 	})
+	conn.results.add(queryResult)
+	start := time.Now()
 	status := C.kuzu_connection_execute(&conn.cConnection, &preparedStatement.cPreparedStatement, &queryResult.cQueryResult)
 	if status != C.KuzuSuccess || !C.kuzu_query_result_is_success(&queryResult.cQueryResult) {
 		cErrMsg := C.kuzu_query_result_get_error_message(&queryResult.cQueryResult)
 		defer C.kuzu_destroy_string(cErrMsg)
-		return queryResult, fmt.Errorf(C.GoString(cErrMsg))
+		return queryResult, conn.queryError(C.GoString(cErrMsg), preparedStatement.query, start)
 	}
 	return queryResult, nil
 }
@@ -115,33 +339,256 @@ func (conn *Connection) bindParameter(preparedStatement *PreparedStatement, key
 	var status C.kuzu_state
 	var cValue *C.kuzu_value
 	var valueConversionError error
-	cValue, valueConversionError = goValueToKuzuValue(value)
+	cValue, valueConversionError = goValueToKuzuValue(value, conn.valueOptions)
+	if queried, ok := valueConversionError.(*queriedValueError); ok {
+		cValue, valueConversionError = conn.queryValue(queried)
+	}
 	if valueConversionError != nil {
-		return fmt.Errorf("failed to convert Go value to Kuzu value: %v", valueConversionError)
+		return newError(ErrorKindConversion, "failed to convert Go value to Kuzu value for parameter %q: %w", key, valueConversionError)
 	}
 	defer C.kuzu_value_destroy(cValue)
 	status = C.kuzu_prepared_statement_bind_value(&preparedStatement.cPreparedStatement, cKey, cValue)
 	if status != C.KuzuSuccess {
-		return fmt.Errorf("failed to bind value with status %d", status)
+		return newError(ErrorKindBinder, "failed to bind value for parameter %q with status %d", key, status)
 	}
 	return nil
 }
 
+// queriedValueError is returned by goValueToKuzuValue for a value that the C
+// API cannot create, such as a DECIMAL. If the value is the one of a
+// parameter, the connection creates it by running query with the STRING value
+// bound to $value instead. Otherwise, err is returned.
+type queriedValueError struct {
+	query string
+	value string
+	err   error
+}
+
+func (err *queriedValueError) Error() string {
+	return err.err.Error()
+}
+
+func (err *queriedValueError) Unwrap() error {
+	return err.err
+}
+
+// queryValue creates the value described by the queriedValueError with its
+// query. The statement lock of the connection must be held.
+func (conn *Connection) queryValue(queried *queriedValueError) (*C.kuzu_value, error) {
+	cQuery := C.CString(queried.query)
+	defer C.free(unsafe.Pointer(cQuery))
+	var cPreparedStatement C.kuzu_prepared_statement
+	status := C.kuzu_connection_prepare(&conn.cConnection, cQuery, &cPreparedStatement)
+	defer C.kuzu_prepared_statement_destroy(&cPreparedStatement)
+	if status != C.KuzuSuccess || !C.kuzu_prepared_statement_is_success(&cPreparedStatement) {
+		return nil, newError(ErrorKindConversion, "failed to prepare %q with status %d", queried.query, status)
+	}
+	cKey := C.CString("value")
+	defer C.free(unsafe.Pointer(cKey))
+	cValueString := C.CString(queried.value)
+	defer C.free(unsafe.Pointer(cValueString))
+	C.kuzu_prepared_statement_bind_string(&cPreparedStatement, cKey, cValueString)
+	var cQueryResult C.kuzu_query_result
+	status = C.kuzu_connection_execute(&conn.cConnection, &cPreparedStatement, &cQueryResult)
+	defer C.kuzu_query_result_destroy(&cQueryResult)
+	if status != C.KuzuSuccess || !C.kuzu_query_result_is_success(&cQueryResult) {
+		cErrMsg := C.kuzu_query_result_get_error_message(&cQueryResult)
+		defer C.kuzu_destroy_string(cErrMsg)
+		return nil, newError(ErrorKindConversion, "failed to convert %q with %q: %s", queried.value, queried.query, C.GoString(cErrMsg))
+	}
+	var cFlatTuple C.kuzu_flat_tuple
+	if C.kuzu_query_result_get_next(&cQueryResult, &cFlatTuple) != C.KuzuSuccess {
+		return nil, newError(ErrorKindConversion, "failed to read the result of %q", queried.query)
+	}
+	defer C.kuzu_flat_tuple_destroy(&cFlatTuple)
+	var cValue C.kuzu_value
+	if C.kuzu_flat_tuple_get_value(&cFlatTuple, 0, &cValue) != C.KuzuSuccess {
+		return nil, newError(ErrorKindConversion, "failed to read the result of %q", queried.query)
+	}
+	defer C.kuzu_value_destroy(&cValue)
+	// The value is copied, as it is owned by the query result.
+	var cLogicalType C.kuzu_logical_type
+	C.kuzu_value_get_data_type(&cValue, &cLogicalType)
+	defer C.kuzu_data_type_destroy(&cLogicalType)
+	kuzuValue := C.kuzu_value_create_null_with_data_type(&cLogicalType)
+	C.kuzu_value_copy(kuzuValue, &cValue)
+	return kuzuValue, nil
+}
+
 // Prepare returns a prepared statement for the specified query string.
 // The prepared statement can be used to execute the query with parameters.
 func (conn *Connection) Prepare(query string) (*PreparedStatement, error) {
+	if err := conn.lock(); err != nil {
+		return nil, err
+	}
+	defer conn.mu.Unlock()
+	return conn.prepare(query)
+}
+
+// prepare prepares the specified query string. The caller must hold the
+// statement lock.
+func (conn *Connection) prepare(query string) (*PreparedStatement, error) {
 	cQuery := C.CString(query)
 	defer C.free(unsafe.Pointer(cQuery))
 	preparedStatement := &PreparedStatement{}
 	preparedStatement.connection = conn
+	preparedStatement.query = query
 	runtime.SetFinalizer(preparedStatement, func(preparedStatement *PreparedStatement) {
 		preparedStatement.Close()
 	})
+	conn.statements.add(preparedStatement)
 	status := C.kuzu_connection_prepare(&conn.cConnection, cQuery, &preparedStatement.cPreparedStatement)
 	if status != C.KuzuSuccess || !C.kuzu_prepared_statement_is_success(&preparedStatement.cPreparedStatement) {
 		cErrMsg := C.kuzu_prepared_statement_get_error_message(&preparedStatement.cPreparedStatement)
 		defer C.kuzu_destroy_string(cErrMsg)
-		return preparedStatement, fmt.Errorf(C.GoString(cErrMsg))
+		return preparedStatement, newQueryError(C.GoString(cErrMsg), query)
 	}
 	return preparedStatement, nil
 }
+
+// QueryContext executes the specified query string and returns the result.
+// If the context is cancelled or its deadline passes before the query
+// completes, the query is interrupted and the returned error wraps ctx.Err().
+func (conn *Connection) QueryContext(ctx context.Context, query string) (*QueryResult, error) {
+	if err := conn.lock(); err != nil {
+		return nil, err
+	}
+	defer conn.mu.Unlock()
+	return conn.queryContext(ctx, query)
+}
+
+// queryContext executes the specified query string, watching the context.
+// The caller must hold the statement lock.
+func (conn *Connection) queryContext(ctx context.Context, query string) (*QueryResult, error) {
+	var queryResult *QueryResult
+	err := conn.runWithContext(ctx, func() error {
+		var err error
+		queryResult, err = conn.query(query)
+		return err
+	})
+	return queryResult, err
+}
+
+// ExecuteContext executes the specified prepared statement with the specified
+// arguments and returns the result. If the context is cancelled or its
+// deadline passes before the execution completes, the query is interrupted
+// and the returned error wraps ctx.Err().
+func (conn *Connection) ExecuteContext(ctx context.Context, preparedStatement *PreparedStatement, args map[string]any) (*QueryResult, error) {
+	if err := conn.lock(); err != nil {
+		return nil, err
+	}
+	defer conn.mu.Unlock()
+	return conn.executeContext(ctx, preparedStatement, args)
+}
+
+// executeContext executes the specified prepared statement with the
+// specified arguments, watching the context. The caller must hold the
+// statement lock.
+func (conn *Connection) executeContext(ctx context.Context, preparedStatement *PreparedStatement, args map[string]any) (*QueryResult, error) {
+	var queryResult *QueryResult
+	err := conn.runWithContext(ctx, func() error {
+		var err error
+		queryResult, err = conn.execute(preparedStatement, args)
+		return err
+	})
+	return queryResult, err
+}
+
+// PrepareContext returns a prepared statement for the specified query string.
+// If the context is cancelled or its deadline passes before the statement is
+// prepared, the returned error wraps ctx.Err().
+func (conn *Connection) PrepareContext(ctx context.Context, query string) (*PreparedStatement, error) {
+	if err := conn.lock(); err != nil {
+		return nil, err
+	}
+	defer conn.mu.Unlock()
+	var preparedStatement *PreparedStatement
+	err := conn.runWithContext(ctx, func() error {
+		var err error
+		preparedStatement, err = conn.prepare(query)
+		return err
+	})
+	return preparedStatement, err
+}
+
+// runWithContext runs fn while watching the context. The running query is
+// interrupted as soon as the context is done. A deadline on the context is
+// also mapped onto the query timeout of the connection for the duration of
+// fn, so that Kuzu stops the query on its own even if the interrupt is late.
+// The caller must hold the statement lock.
+func (conn *Connection) runWithContext(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return contextError(err, nil)
+	}
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return contextError(context.DeadlineExceeded, nil)
+		}
+		// Round up so that Kuzu never gives up before the deadline has passed.
+		timeout := uint64((remaining + time.Millisecond - 1) / time.Millisecond)
+		if conn.timeout == 0 || timeout < conn.timeout {
+			previousTimeout := conn.timeout
+			conn.setTimeout(timeout)
+			defer conn.setTimeout(previousTimeout)
+		}
+	}
+	done := ctx.Done()
+	if done == nil {
+		return fn()
+	}
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-done:
+			conn.Interrupt()
+		case <-stop:
+		}
+	}()
+	err := fn()
+	close(stop)
+	<-stopped
+	if err == nil {
+		return nil
+	}
+	ctxErr := ctx.Err()
+	if ctxErr == nil && hasDeadline && !time.Now().Before(deadline) {
+		ctxErr = context.DeadlineExceeded
+	}
+	if ctxErr != nil {
+		return contextError(ctxErr, err)
+	}
+	return err
+}
+
+// queryError returns the Error for the message of an exception raised by Kuzu
+// while executing the query started at the given time. An interruption is
+// reported as a timeout if the timeout of the connection has elapsed.
+func (conn *Connection) queryError(message string, query string, start time.Time) *Error {
+	err := newQueryError(message, query)
+	if err.Kind == ErrorKindInterrupted && conn.timeout > 0 && time.Since(start) >= time.Duration(conn.timeout)*time.Millisecond {
+		err.Kind = ErrorKindTimeout
+	}
+	return err
+}
+
+// contextError returns the Error reported when the context of an operation is
+// done, wrapping ctxErr so that errors.Is matches it. err is the error
+// returned by the interrupted operation, if any.
+func contextError(ctxErr error, err error) *Error {
+	kind := ErrorKindInterrupted
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		kind = ErrorKindTimeout
+	}
+	var kuzuErr *Error
+	if errors.As(err, &kuzuErr) {
+		return &Error{Kind: kind, Message: kuzuErr.Message, Query: kuzuErr.Query, Err: ctxErr}
+	}
+	if err != nil {
+		return &Error{Kind: kind, Message: err.Error(), Err: ctxErr}
+	}
+	return &Error{Kind: kind, Message: ctxErr.Error(), Err: ctxErr}
+}
@@ -1,6 +1,8 @@
 package kuzu
 
 import (
+	"context"
+	"errors"
 	"runtime"
 	"sync"
 	"testing"
@@ -168,3 +170,233 @@ func TestExecuteError(t *testing.T) {
 	stmt.Close()
 	conn.Close()
 }
+
+func TestQueryContext(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, _ := OpenConnection(db)
+	result, err := conn.QueryContext(context.Background(), "RETURN CAST(1, \"INT64\");")
+	assert.Nil(t, err)
+	assert.True(t, result.HasNext())
+	flatTuple, err := result.Next()
+	assert.Nil(t, err)
+	value, err := flatTuple.GetValue(0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+	result.Close()
+	conn.Close()
+}
+
+func TestQueryContextCancel(t *testing.T) {
+	// TODO: Fix this test on Windows
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping test on Windows")
+	}
+	db, _ := SetupTestDatabase(t)
+	conn, _ := OpenConnection(db)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+	_, err := conn.QueryContext(ctx, largeQuery)
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Contains(t, err.Error(), "Interrupted.")
+	// The connection must still be usable after the interruption.
+	result, err := conn.Query("RETURN 1;")
+	assert.Nil(t, err)
+	result.Close()
+	conn.Close()
+}
+
+func TestQueryContextDeadline(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, _ := OpenConnection(db)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := conn.QueryContext(ctx, largeQuery)
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	// The timeout of the connection is restored after the query.
+	assert.Equal(t, uint64(0), conn.timeout)
+	conn.Close()
+}
+
+func TestQueryContextAlreadyDone(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, _ := OpenConnection(db)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := conn.QueryContext(ctx, "RETURN 1;")
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, context.Canceled))
+	conn.Close()
+}
+
+func TestPrepareContextAndExecuteContext(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, _ := OpenConnection(db)
+	ctx := context.Background()
+	stmt, err := conn.PrepareContext(ctx, "RETURN $a;")
+	assert.Nil(t, err)
+	result, err := conn.ExecuteContext(ctx, stmt, map[string]any{"a": int64(1)})
+	assert.Nil(t, err)
+	assert.True(t, result.HasNext())
+	flatTuple, err := result.Next()
+	assert.Nil(t, err)
+	value, err := flatTuple.GetValue(0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+	result.Close()
+	stmt.Close()
+	conn.Close()
+}
+
+func TestConnectionConcurrentQueries(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	defer conn.Close()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				res, err := conn.QueryContext(context.Background(), "RETURN 1 + 1;")
+				if !assert.Nil(t, err) {
+					return
+				}
+				tuple, err := res.Next()
+				assert.Nil(t, err)
+				value, err := tuple.GetValue(0)
+				assert.Nil(t, err)
+				assert.Equal(t, int64(2), value)
+				tuple.Close()
+				res.Close()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConnectionConcurrentClose(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				res, err := conn.Query("MATCH (a:person) RETURN a.fName;")
+				if err != nil {
+					assert.True(t, errors.Is(err, ErrClosed))
+					return
+				}
+				res.Close()
+				conn.Interrupt()
+			}
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn.Close()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConnectionClosed(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	stmt, err := conn.Prepare("RETURN 1;")
+	assert.Nil(t, err)
+	conn.Close()
+	_, err = conn.Query("RETURN 1;")
+	assert.True(t, errors.Is(err, ErrClosed))
+	_, err = conn.QueryContext(context.Background(), "RETURN 1;")
+	assert.True(t, errors.Is(err, ErrClosed))
+	_, err = conn.Prepare("RETURN 1;")
+	assert.True(t, errors.Is(err, ErrClosed))
+	_, err = conn.Execute(stmt, nil)
+	assert.True(t, errors.Is(err, ErrClosed))
+	_, err = conn.Begin(TransactionOptions{})
+	assert.True(t, errors.Is(err, ErrClosed))
+	assert.Equal(t, uint64(0), conn.GetMaxNumThreads())
+	conn.SetTimeout(1000)
+	conn.SetMaxNumThreads(1)
+	conn.Interrupt()
+	stmt.Close()
+}
+
+func TestExecuteClosedPreparedStatement(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	stmt, err := conn.Prepare("RETURN 1;")
+	assert.Nil(t, err)
+	stmt.Close()
+	stmt.Close()
+	_, err = conn.Execute(stmt, nil)
+	assert.True(t, errors.Is(err, ErrClosed))
+}
+
+func TestCloseConnectionClosesResults(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	res, err := conn.Query("MATCH (a:person) RETURN a.fName;")
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	stmt, err := conn.Prepare("RETURN 1;")
+	assert.Nil(t, err)
+	conn.Close()
+	assert.False(t, res.HasNext())
+	_, err = res.Next()
+	assert.True(t, errors.Is(err, ErrClosed))
+	_, err = tuple.GetValue(0)
+	assert.True(t, errors.Is(err, ErrClosed))
+	assert.Equal(t, "", tuple.GetAsString())
+	assert.True(t, stmt.isClosed)
+	tuple.Close()
+	res.Close()
+}
+
+func TestConnectionDoesNotRetainResults(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	defer conn.Close()
+	for i := 0; i < 10; i++ {
+		_, err := conn.Query("RETURN 1;")
+		assert.Nil(t, err)
+	}
+	// The query results that are not closed are still garbage collected.
+	runtime.GC()
+	assert.Empty(t, conn.results.drain())
+}
+
+func TestConnectionForgetsCollectedResults(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	defer conn.Close()
+	for i := 0; i < 1000; i++ {
+		_, err := conn.Query("RETURN 1;")
+		assert.Nil(t, err)
+	}
+	// The finalizers of the query results that are not closed remove them
+	// from the connection once they are garbage collected.
+	numResults := func() int {
+		conn.results.mu.Lock()
+		defer conn.results.mu.Unlock()
+		return len(conn.results.items)
+	}
+	for i := 0; i < 100 && numResults() > 10; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, numResults(), 10)
+}
@@ -6,8 +6,8 @@ package kuzu
 // #include <stdlib.h>
 import "C"
 import (
-	"fmt"
 	"runtime"
+	"sync"
 	"unsafe"
 )
 
@@ -56,8 +56,14 @@ func (config SystemConfig) toC() C.kuzu_system_config {
 
 // Database represents a Kuzu database instance.
 type Database struct {
-	cDatabase C.kuzu_database
-	isClosed  bool
+	cDatabase   C.kuzu_database
+	isClosed    bool
+	connections children[Connection]
+	// mu guards the database against Close while connections are opened.
+	mu sync.RWMutex
+	// handleMu guards the C database against Close for the connections that
+	// destroy their C handles.
+	handleMu sync.RWMutex
 }
 
 // OpenDatabase opens a Kuzu database at the given path with the given system configuration.
@@ -71,7 +77,7 @@ func OpenDatabase(path string, systemConfig SystemConfig) (*Database, error) {
 	cSystemConfig := systemConfig.toC()
 	status := C.kuzu_database_init(cPath, cSystemConfig, &db.cDatabase)
 	if status != C.KuzuSuccess {
-		return db, fmt.Errorf("failed to open database with status %d", status)
+		return db, newError(ErrorKindConnection, "failed to open database with status %d", status)
 	}
 	return db, nil
 }
@@ -83,10 +89,20 @@ func OpenInMemoryDatabase(systemConfig SystemConfig) (*Database, error) {
 
 // Close closes the database. Calling this method is optional.
 // The database will be closed automatically when it is garbage collected.
+// The connections opened on the database that are still open are closed
+// first, together with their query results and prepared statements.
+// It is safe to call Close multiple times and concurrently.
 func (db *Database) Close() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
 	if db.isClosed {
 		return
 	}
+	for _, conn := range db.connections.drain() {
+		conn.Close()
+	}
+	db.handleMu.Lock()
+	defer db.handleMu.Unlock()
 	C.kuzu_database_destroy(&db.cDatabase)
 	db.isClosed = true
 }
@@ -1,6 +1,7 @@
 package kuzu
 
 import (
+	"errors"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -88,3 +89,34 @@ func TestCloseDatabase(t *testing.T) {
 	db.Close()
 	assert.True(t, db.isClosed)
 }
+
+func TestCloseDatabaseClosesConnections(t *testing.T) {
+	db, err := OpenInMemoryDatabase(DefaultSystemConfig())
+	assert.Nil(t, err)
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	stmt, err := conn.Prepare("RETURN $x;")
+	assert.Nil(t, err)
+	res, err := conn.Query("UNWIND [1, 2, 3] AS x RETURN x;")
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	db.Close()
+	assert.True(t, conn.isClosed)
+	assert.True(t, res.isClosed)
+	assert.True(t, stmt.isClosed)
+	_, err = tuple.GetValue(0)
+	assert.True(t, errors.Is(err, ErrClosed))
+	_, err = res.Next()
+	assert.True(t, errors.Is(err, ErrClosed))
+	_, err = conn.Query("RETURN 1;")
+	assert.True(t, errors.Is(err, ErrClosed))
+	_, err = OpenConnection(db)
+	assert.True(t, errors.Is(err, ErrClosed))
+	// Closing the children after the database is a no-op.
+	tuple.Close()
+	res.Close()
+	stmt.Close()
+	conn.Close()
+	db.Close()
+}
@@ -4,16 +4,28 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
-	"fmt"
+	"errors"
 	"io"
+	"math"
+	"math/big"
 	"net/url"
+	"reflect"
 	"strconv"
 	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 func init() {
 	var _ driver.Result = new(resultSet)
 	var _ driver.Rows = new(rowSet)
+	var _ driver.RowsColumnTypeDatabaseTypeName = new(rowSet)
+	var _ driver.RowsColumnTypeScanType = new(rowSet)
+	var _ driver.RowsColumnTypeNullable = new(rowSet)
+	var _ driver.RowsColumnTypePrecisionScale = new(rowSet)
+	var _ driver.RowsColumnTypeLength = new(rowSet)
 	var _ SQLConnection = new(connection)
 	var _ SQLStatement = new(statement)
 	var _ SQLConnector = new(connector)
@@ -39,6 +51,7 @@ type SQLConnection interface {
 	driver.ConnPrepareContext
 	driver.QueryerContext
 	driver.ExecerContext
+	driver.ConnBeginTx
 }
 
 type SQLConnector interface {
@@ -51,7 +64,9 @@ type sqlDriver struct {
 	cc map[string]driver.Connector
 }
 
-// OpenConnector kuzu://path?poolSize=1024&threads=1024&dbSize=1024&compression=1&readOnly=1
+// OpenConnector kuzu://path?poolSize=1024&threads=1024&dbSize=1024&compression=1&readOnly=1&intervalAsDuration=1
+// With intervalAsDuration=1, INTERVAL values are returned as time.Duration
+// values, as with ValueOptions.IntervalAsDuration.
 func (that *sqlDriver) OpenConnector(dsn string) (driver.Connector, error) {
 	u, err := url.Parse(dsn)
 	if nil != err {
@@ -84,15 +99,24 @@ func (that *sqlDriver) OpenConnector(dsn string) (driver.Connector, error) {
 	}); nil != err {
 		return nil, err
 	}
+	valueOptions := ValueOptions{}
+	if err = parse(q.Get("intervalAsDuration"), func(v uint64) {
+		valueOptions.IntervalAsDuration = v == uint64(1)
+	}); nil != err {
+		return nil, err
+	}
 	db, err := OpenDatabase(u.Path, systemConfig)
 	if nil != err {
-		release(db)
+		if nil != db {
+			release(db)
+		}
 		return nil, err
 	}
 	return &connector{
-		d:   that,
-		dsn: dsn,
-		db:  db,
+		d:            that,
+		dsn:          dsn,
+		db:           db,
+		valueOptions: valueOptions,
 	}, nil
 }
 
@@ -117,9 +141,10 @@ func (that *sqlDriver) Open(dsn string) (driver.Conn, error) {
 }
 
 type connector struct {
-	dsn string
-	d   driver.Driver
-	db  *Database
+	dsn          string
+	d            driver.Driver
+	db           *Database
+	valueOptions ValueOptions
 }
 
 func (that *connector) Close() error {
@@ -134,9 +159,12 @@ func (that *connector) Driver() driver.Driver {
 func (that *connector) Connect(ctx context.Context) (driver.Conn, error) {
 	conn, err := OpenConnection(that.db)
 	if nil != err {
-		release(conn)
+		if nil != conn {
+			release(conn)
+		}
 		return nil, err
 	}
+	conn.SetValueOptions(that.valueOptions)
 	return &connection{
 		conn: conn,
 	}, nil
@@ -147,7 +175,66 @@ type connection struct {
 }
 
 func (that *connection) Ping(ctx context.Context) error {
-	return nil
+	return ctx.Err()
+}
+
+// nativeParameterTypes are the types that Execute binds as a Kuzu type of
+// their own, even though the default conversion of database/sql supports them
+// through driver.Valuer or their kind, e.g. a uuid.UUID as a UUID rather than
+// a STRING and a time.Duration as an INTERVAL rather than an INT64.
+var nativeParameterTypes = map[reflect.Type]bool{
+	reflect.TypeOf(uuid.UUID{}):       true,
+	reflect.TypeOf(decimal.Decimal{}): true,
+	reflect.TypeOf(big.Int{}):         true,
+	reflect.TypeOf(time.Duration(0)):  true,
+	reflect.TypeOf(Date{}):            true,
+	reflect.TypeOf(TimestampTZ{}):     true,
+	reflect.TypeOf(TimestampNs{}):     true,
+	reflect.TypeOf(TimestampMs{}):     true,
+	reflect.TypeOf(TimestampSec{}):    true,
+	reflect.TypeOf(Int128{}):          true,
+	reflect.TypeOf(Decimal{}):         true,
+	reflect.TypeOf(Interval{}):        true,
+	reflect.TypeOf(Union{}):           true,
+	reflect.TypeOf(TypedValue{}):      true,
+}
+
+// isNativeParameter returns true if the value, or the value it points to, has
+// one of the nativeParameterTypes.
+func isNativeParameter(value any) bool {
+	valueType := reflect.TypeOf(value)
+	for valueType != nil && valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	return nativeParameterTypes[valueType]
+}
+
+// CheckNamedValue passes the arguments that the default conversion of
+// database/sql does not support as is to the statement: the values with an
+// encoder registered with RegisterEncoder or implementing Marshaler, the
+// values of the types that Kuzu supports natively, such as a uuid.UUID, a
+// decimal.Decimal, a *big.Int or the wrappers of the package, the pointers,
+// which are bound as typed NULLs when nil, the structs, the maps and the
+// slices. The other arguments, including the other driver.Valuer
+// implementations, are left to the default conversion.
+func (that *connection) CheckNamedValue(nv *driver.NamedValue) error {
+	if _, ok := encoders.Load(reflect.TypeOf(nv.Value)); ok {
+		return nil
+	}
+	if isNativeParameter(nv.Value) {
+		return nil
+	}
+	switch nv.Value.(type) {
+	case Marshaler:
+		return nil
+	case driver.Valuer, []byte:
+		return driver.ErrSkip
+	}
+	switch reflect.ValueOf(nv.Value).Kind() {
+	case reflect.Ptr, reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return nil
+	}
+	return driver.ErrSkip
 }
 
 func (that *connection) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
@@ -177,9 +264,11 @@ func (that *connection) Prepare(query string) (driver.Stmt, error) {
 }
 
 func (that *connection) prepareContext(ctx context.Context, query string) (SQLStatement, error) {
-	stmt, err := that.conn.Prepare(query)
+	stmt, err := that.conn.PrepareContext(ctx, query)
 	if nil != err {
-		release(stmt)
+		if nil != stmt {
+			release(stmt)
+		}
 		return nil, err
 	}
 	return &statement{
@@ -196,8 +285,24 @@ func (that *connection) Close() error {
 }
 
 func (that *connection) Begin() (driver.Tx, error) {
+	return that.BeginTx(nextContext(), driver.TxOptions{})
+}
+
+// BeginTx starts a Kuzu transaction on the connection. Kuzu transactions are
+// serializable, so only the default and serializable isolation levels are
+// accepted.
+func (that *connection) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	switch level := sql.IsolationLevel(opts.Isolation); level {
+	case sql.LevelDefault, sql.LevelSerializable:
+	default:
+		return nil, newError(ErrorKindInvalidArgument, "unsupported isolation level: %s", level)
+	}
+	tx, err := that.conn.beginContext(ctx, TransactionOptions{ReadOnly: opts.ReadOnly})
+	if nil != err {
+		return nil, err
+	}
 	return &transaction{
-		conn: that,
+		tx: tx,
 	}, nil
 }
 
@@ -222,9 +327,11 @@ func (that *statement) ExecContext(ctx context.Context, args []driver.NamedValue
 	for _, arg := range args {
 		raw[arg.Name] = arg.Value
 	}
-	rs, err := that.conn.Execute(that.stmt, raw)
+	rs, err := that.conn.ExecuteContext(ctx, that.stmt, raw)
 	if nil != err {
-		release(rs)
+		if nil != rs {
+			release(rs)
+		}
 		return nil, err
 	}
 	defer rs.Close()
@@ -240,7 +347,7 @@ func (that *statement) Exec(args []driver.Value) (driver.Result, error) {
 	for i, v := range args {
 		na, ok := v.(sql.NamedArg)
 		if !ok {
-			return nil, fmt.Errorf("only support named arguments")
+			return nil, newError(ErrorKindInvalidArgument, "only support named arguments")
 		}
 		list[i] = driver.NamedValue{
 			Name:    na.Name,
@@ -256,9 +363,11 @@ func (that *statement) QueryContext(ctx context.Context, args []driver.NamedValu
 	for _, arg := range args {
 		raw[arg.Name] = arg.Value
 	}
-	rs, err := that.conn.Execute(that.stmt, raw)
+	rs, err := that.conn.ExecuteContext(ctx, that.stmt, raw)
 	if nil != err {
-		release(rs)
+		if nil != rs {
+			release(rs)
+		}
 		return nil, err
 	}
 	return &rowSet{rs: rs}, nil
@@ -269,7 +378,7 @@ func (that *statement) Query(args []driver.Value) (driver.Rows, error) {
 	for i, v := range args {
 		na, ok := v.(sql.NamedArg)
 		if !ok {
-			return nil, fmt.Errorf("only support named arguments")
+			return nil, newError(ErrorKindInvalidArgument, "only support named arguments")
 		}
 		list[i] = driver.NamedValue{
 			Name:    na.Name,
@@ -280,17 +389,24 @@ func (that *statement) Query(args []driver.Value) (driver.Rows, error) {
 	return that.QueryContext(nextContext(), list)
 }
 
-// transaction is not support by now.
+// transaction is a Kuzu transaction pinned to a single connection.
 type transaction struct {
-	conn SQLConnection
+	tx *Transaction
 }
 
 func (that *transaction) Commit() error {
-	return nil
+	return txDone(that.tx.Commit())
 }
 
 func (that *transaction) Rollback() error {
-	return nil
+	return txDone(that.tx.Rollback())
+}
+
+func txDone(err error) error {
+	if errors.Is(err, ErrTransactionDone) {
+		return sql.ErrTxDone
+	}
+	return err
 }
 
 type rowSet struct {
@@ -301,6 +417,73 @@ func (that *rowSet) Columns() []string {
 	return that.rs.GetColumnNames()
 }
 
+// ColumnTypeDatabaseTypeName returns the Kuzu type name of the column without
+// its parameters, e.g. "INT64", "DECIMAL" or "LIST".
+func (that *rowSet) ColumnTypeDatabaseTypeName(index int) string {
+	columnType, ok := that.columnType(index)
+	if !ok {
+		return ""
+	}
+	return columnType.ID.String()
+}
+
+// ColumnTypeScanType returns the Go type of the values returned for the column.
+func (that *rowSet) ColumnTypeScanType(index int) reflect.Type {
+	columnType, ok := that.columnType(index)
+	if !ok {
+		return reflect.TypeOf((*any)(nil)).Elem()
+	}
+	if columnType.ID == TypeInterval && that.rs.valueOptions.IntervalAsDuration {
+		return reflect.TypeOf(time.Duration(0))
+	}
+	return scanTypeOf(columnType.ID)
+}
+
+// ColumnTypeNullable reports that every column may be NULL, because Kuzu does
+// not expose NOT NULL constraints of the result columns.
+func (that *rowSet) ColumnTypeNullable(index int) (nullable, ok bool) {
+	if _, ok := that.columnType(index); !ok {
+		return false, false
+	}
+	return true, true
+}
+
+// ColumnTypePrecisionScale returns the precision and the scale of DECIMAL
+// columns.
+func (that *rowSet) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	columnType, ok := that.columnType(index)
+	if !ok || columnType.ID != TypeDecimal {
+		return 0, 0, false
+	}
+	return int64(columnType.Precision), int64(columnType.Scale), true
+}
+
+// ColumnTypeLength returns the length of STRING and BLOB columns, which is
+// unbounded, and the number of elements of ARRAY columns.
+func (that *rowSet) ColumnTypeLength(index int) (length int64, ok bool) {
+	columnType, ok := that.columnType(index)
+	if !ok {
+		return 0, false
+	}
+	switch columnType.ID {
+	case TypeString, TypeBlob:
+		return math.MaxInt64, true
+	case TypeArray:
+		return int64(columnType.ArrayLength), true
+	}
+	return 0, false
+}
+
+// columnType returns the type of the column at the given index, and false if
+// there is no such column.
+func (that *rowSet) columnType(index int) (LogicalType, bool) {
+	columnTypes := that.rs.GetColumnTypes()
+	if index < 0 || index >= len(columnTypes) {
+		return LogicalType{}, false
+	}
+	return columnTypes[index], true
+}
+
 func (that *rowSet) Close() error {
 	that.rs.Close()
 	return nil
@@ -312,7 +495,9 @@ func (that *rowSet) Next(dest []driver.Value) error {
 	}
 	row, err := that.rs.Next()
 	if nil != err {
-		release(row)
+		if nil != row {
+			release(row)
+		}
 		return err
 	}
 	defer row.Close()
@@ -343,11 +528,13 @@ func (that *resultSet) RowsAffected() (int64, error) {
 	return that.rowsAffected, nil
 }
 
-// Release C resource
+// Release C resource. The callers must not pass a nil pointer, which would
+// make a non-nil Finalizer.
 func release(f Finalizer) {
-	if nil != f {
-		f.Close()
+	if nil == f {
+		return
 	}
+	f.Close()
 }
 
 func nextContext() context.Context {
@@ -358,6 +545,50 @@ func closeQuiet(closer io.Closer) {
 	_ = closer.Close()
 }
 
+var scanTypes = map[TypeID]reflect.Type{
+	TypeBool:         reflect.TypeOf(false),
+	TypeSerial:       reflect.TypeOf(int64(0)),
+	TypeInt64:        reflect.TypeOf(int64(0)),
+	TypeInt32:        reflect.TypeOf(int32(0)),
+	TypeInt16:        reflect.TypeOf(int16(0)),
+	TypeInt8:         reflect.TypeOf(int8(0)),
+	TypeUint64:       reflect.TypeOf(uint64(0)),
+	TypeUint32:       reflect.TypeOf(uint32(0)),
+	TypeUint16:       reflect.TypeOf(uint16(0)),
+	TypeUint8:        reflect.TypeOf(uint8(0)),
+	TypeInt128:       reflect.TypeOf(new(big.Int)),
+	TypeDouble:       reflect.TypeOf(float64(0)),
+	TypeFloat:        reflect.TypeOf(float32(0)),
+	TypeDate:         reflect.TypeOf(time.Time{}),
+	TypeTimestamp:    reflect.TypeOf(time.Time{}),
+	TypeTimestampSec: reflect.TypeOf(time.Time{}),
+	TypeTimestampMs:  reflect.TypeOf(time.Time{}),
+	TypeTimestampNs:  reflect.TypeOf(time.Time{}),
+	TypeTimestampTz:  reflect.TypeOf(time.Time{}),
+	TypeInterval:     reflect.TypeOf(Interval{}),
+	TypeDecimal:      reflect.TypeOf(decimal.Decimal{}),
+	TypeInternalID:   reflect.TypeOf(InternalID{}),
+	TypeString:       reflect.TypeOf(""),
+	TypeBlob:         reflect.TypeOf([]byte(nil)),
+	TypeUUID:         reflect.TypeOf(uuid.UUID{}),
+	TypeNode:         reflect.TypeOf(Node{}),
+	TypeRel:          reflect.TypeOf(Relationship{}),
+	TypeRecursiveRel: reflect.TypeOf(RecursiveRelationship{}),
+	TypeList:         reflect.TypeOf([]any(nil)),
+	TypeArray:        reflect.TypeOf([]any(nil)),
+	TypeStruct:       reflect.TypeOf(map[string]any(nil)),
+	TypeUnion:        reflect.TypeOf(Union{}),
+	TypeMap:          reflect.TypeOf([]MapItem(nil)),
+}
+
+// scanTypeOf returns the Go type that values of the Kuzu type are converted to.
+func scanTypeOf(id TypeID) reflect.Type {
+	if scanType, ok := scanTypes[id]; ok {
+		return scanType
+	}
+	return reflect.TypeOf((*any)(nil)).Elem()
+}
+
 func parse(v string, fn func(v uint64)) error {
 	if "" == v {
 		return nil
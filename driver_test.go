@@ -3,9 +3,17 @@ package kuzu
 import (
 	"database/sql"
 	"fmt"
+	"math"
+	"math/big"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDriver(t *testing.T) {
@@ -93,3 +101,192 @@ func TestDriver(t *testing.T) {
 		t.Log("Rows:" + fmt.Sprint(rs))
 	}
 }
+
+func openTestDriver(t *testing.T) *sql.DB {
+	t.Helper()
+	cc, err := sql.Open(Name, fmt.Sprintf("kuzu://%s", getDatabasePath(t)))
+	if nil != err {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		closeQuiet(cc)
+	})
+	if _, err = cc.Exec("CREATE NODE TABLE User(name STRING, age INT64, PRIMARY KEY (name))"); nil != err {
+		t.Fatal(err)
+	}
+	return cc
+}
+
+func countUsers(t *testing.T, cc *sql.DB) int64 {
+	t.Helper()
+	var count int64
+	if err := cc.QueryRow("MATCH (a:User) RETURN COUNT(*)").Scan(&count); nil != err {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestDriverTransactionCommit(t *testing.T) {
+	cc := openTestDriver(t)
+	tx, err := cc.Begin()
+	assert.Nil(t, err)
+	_, err = tx.Exec("CREATE (:User {name: 'Alice', age: 30})")
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Commit())
+	assert.Equal(t, int64(1), countUsers(t, cc))
+	assert.ErrorIs(t, tx.Rollback(), sql.ErrTxDone)
+}
+
+func TestDriverTransactionRollback(t *testing.T) {
+	cc := openTestDriver(t)
+	tx, err := cc.Begin()
+	assert.Nil(t, err)
+	_, err = tx.Exec("CREATE (:User {name: 'Bob', age: 40})")
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Rollback())
+	assert.Equal(t, int64(0), countUsers(t, cc))
+}
+
+func TestDriverTransactionReadOnly(t *testing.T) {
+	cc := openTestDriver(t)
+	tx, err := cc.BeginTx(nextContext(), &sql.TxOptions{ReadOnly: true})
+	assert.Nil(t, err)
+	_, err = tx.Exec("CREATE (:User {name: 'Carol', age: 50})")
+	assert.NotNil(t, err)
+	assert.Nil(t, tx.Rollback())
+	assert.Equal(t, int64(0), countUsers(t, cc))
+}
+
+func TestDriverTransactionIsolationLevel(t *testing.T) {
+	cc := openTestDriver(t)
+	_, err := cc.BeginTx(nextContext(), &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unsupported isolation level")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	tx, err := cc.BeginTx(nextContext(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Rollback())
+}
+
+func TestDriverColumnTypes(t *testing.T) {
+	cc := openTestDriver(t)
+	rows, err := cc.Query("RETURN CAST(1, 'INT64') AS a, 'x' AS b, [1, 2] AS c, CAST(1.5, 'DECIMAL(10, 2)') AS d, {x: 1} AS e")
+	assert.Nil(t, err)
+	defer closeQuiet(rows)
+	columnTypes, err := rows.ColumnTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(columnTypes))
+	assert.Equal(t, "INT64", columnTypes[0].DatabaseTypeName())
+	assert.Equal(t, reflect.TypeOf(int64(0)), columnTypes[0].ScanType())
+	nullable, ok := columnTypes[0].Nullable()
+	assert.True(t, nullable)
+	assert.True(t, ok)
+	_, _, ok = columnTypes[0].DecimalSize()
+	assert.False(t, ok)
+	assert.Equal(t, "STRING", columnTypes[1].DatabaseTypeName())
+	assert.Equal(t, reflect.TypeOf(""), columnTypes[1].ScanType())
+	length, ok := columnTypes[1].Length()
+	assert.True(t, ok)
+	assert.Equal(t, int64(math.MaxInt64), length)
+	assert.Equal(t, "LIST", columnTypes[2].DatabaseTypeName())
+	assert.Equal(t, reflect.TypeOf([]any(nil)), columnTypes[2].ScanType())
+	assert.Equal(t, "DECIMAL", columnTypes[3].DatabaseTypeName())
+	precision, scale, ok := columnTypes[3].DecimalSize()
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), precision)
+	assert.Equal(t, int64(2), scale)
+	assert.Equal(t, "STRUCT", columnTypes[4].DatabaseTypeName())
+	assert.Equal(t, reflect.TypeOf(map[string]any(nil)), columnTypes[4].ScanType())
+}
+
+func TestDriverColumnTypesOutOfRange(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	rs, err := conn.Query("RETURN 1")
+	assert.Nil(t, err)
+	rows := &rowSet{rs: rs}
+	defer closeQuiet(rows)
+	for _, index := range []int{-1, 1} {
+		assert.Equal(t, "", rows.ColumnTypeDatabaseTypeName(index))
+		assert.Equal(t, reflect.TypeOf((*any)(nil)).Elem(), rows.ColumnTypeScanType(index))
+		_, ok := rows.ColumnTypeNullable(index)
+		assert.False(t, ok)
+		_, _, ok = rows.ColumnTypePrecisionScale(index)
+		assert.False(t, ok)
+		_, ok = rows.ColumnTypeLength(index)
+		assert.False(t, ok)
+	}
+}
+
+func TestDriverIntervalAsDuration(t *testing.T) {
+	cc, err := sql.Open(Name, fmt.Sprintf("kuzu://%s?intervalAsDuration=1", getDatabasePath(t)))
+	assert.Nil(t, err)
+	defer closeQuiet(cc)
+	rows, err := cc.Query("RETURN INTERVAL('1 day 2 hours')")
+	assert.Nil(t, err)
+	defer closeQuiet(rows)
+	columnTypes, err := rows.ColumnTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, reflect.TypeOf(time.Duration(0)), columnTypes[0].ScanType())
+	assert.True(t, rows.Next())
+	var duration time.Duration
+	assert.Nil(t, rows.Scan(&duration))
+	assert.Equal(t, 26*time.Hour, duration)
+}
+
+func TestDriverNull(t *testing.T) {
+	cc := openTestDriver(t)
+	_, err := cc.Exec("CREATE (:User {name: $name, age: $age})", sql.Named("name", "Adam"), sql.Named("age", Null[int64]{}))
+	assert.Nil(t, err)
+	var age Null[int64]
+	err = cc.QueryRow("MATCH (a:User) WHERE a.name = 'Adam' RETURN a.age").Scan(&age)
+	assert.Nil(t, err)
+	assert.False(t, age.Valid)
+	_, err = cc.Exec("MATCH (a:User) WHERE a.name = 'Adam' SET a.age = $age", sql.Named("age", Null[int64]{V: 30, Valid: true}))
+	assert.Nil(t, err)
+	err = cc.QueryRow("MATCH (a:User) WHERE a.name = 'Adam' RETURN a.age").Scan(&age)
+	assert.Nil(t, err)
+	assert.Equal(t, Null[int64]{V: 30, Valid: true}, age)
+}
+
+func TestDriverNativeParameters(t *testing.T) {
+	cc := openTestDriver(t)
+	moment := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, test := range []struct {
+		value    any
+		typeName string
+	}{
+		{uuid.MustParse("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"), "UUID"},
+		{(*uuid.UUID)(nil), "UUID"},
+		{decimal.RequireFromString("1.5"), "DECIMAL(2, 1)"},
+		{big.NewInt(42), "INT128"},
+		{time.Hour, "INTERVAL"},
+		{Date(moment), "DATE"},
+		{TimestampTZ(moment), "TIMESTAMP_TZ"},
+		{TimestampNs(moment), "TIMESTAMP_NS"},
+		{TimestampMs(moment), "TIMESTAMP_MS"},
+		{TimestampSec(moment), "TIMESTAMP_SEC"},
+		{Int128{big.NewInt(42)}, "INT128"},
+		{Decimal{Value: decimal.RequireFromString("1.5"), Precision: 10, Scale: 2}, "DECIMAL(10, 2)"},
+		{Interval{Months: 1}, "INTERVAL"},
+		{Union{Value: int64(1)}, "INT64"},
+		{TypedValue{Type: LogicalType{ID: TypeInt32}, Value: 1}, "INT32"},
+		{Null[uuid.UUID]{}, "UUID"},
+	} {
+		var typeName string
+		err := cc.QueryRow("RETURN typeof($value)", sql.Named("value", test.value)).Scan(&typeName)
+		assert.Nil(t, err, "%T", test.value)
+		assert.Equal(t, test.typeName, typeName, "%T", test.value)
+	}
+}
+
+func TestDriverSelfReferencingStruct(t *testing.T) {
+	type Category struct {
+		Name   string
+		Parent *Category
+	}
+	cc := openTestDriver(t)
+	var name string
+	err := cc.QueryRow("RETURN $category.Name", sql.Named("category", Category{Name: "root"})).Scan(&name)
+	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, ErrRuntime)
+}
@@ -0,0 +1,184 @@
+package kuzu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorKind classifies the errors returned by Kuzu.
+type ErrorKind int
+
+const (
+	// ErrorKindUnknown is the kind of the errors that cannot be classified.
+	ErrorKindUnknown ErrorKind = iota
+	// ErrorKindParser is the kind of the errors raised when a query cannot be
+	// parsed, e.g. because of a syntax error.
+	ErrorKindParser
+	// ErrorKindBinder is the kind of the errors raised when a query refers to
+	// tables, properties or functions that do not exist or are used incorrectly.
+	ErrorKindBinder
+	// ErrorKindRuntime is the kind of the errors raised while a query is
+	// executed.
+	ErrorKindRuntime
+	// ErrorKindInterrupted is the kind of the errors raised when a query is
+	// interrupted, by Connection.Interrupt or a cancelled context.
+	ErrorKindInterrupted
+	// ErrorKindTimeout is the kind of the errors raised when a query is
+	// interrupted because it exceeded the timeout of the connection or the
+	// deadline of its context.
+	ErrorKindTimeout
+	// ErrorKindConversion is the kind of the errors raised when a value cannot
+	// be converted between Go and Kuzu, or cast between Kuzu types.
+	ErrorKindConversion
+	// ErrorKindClosed is the kind of the errors raised when a closed object is
+	// used.
+	ErrorKindClosed
+	// ErrorKindConnection is the kind of the errors raised when a database or
+	// a connection cannot be opened.
+	ErrorKindConnection
+	// ErrorKindCatalog is the kind of the errors raised by the catalog of the
+	// database, e.g. when a table is created twice.
+	ErrorKindCatalog
+	// ErrorKindConstraint is the kind of the errors raised when a constraint
+	// is violated, e.g. when a primary key is duplicated or NULL.
+	ErrorKindConstraint
+	// ErrorKindInvalidArgument is the kind of the errors raised when a
+	// function of the package is called with an invalid argument, e.g. a
+	// negative chunk size.
+	ErrorKindInvalidArgument
+)
+
+var errorKindNames = map[ErrorKind]string{
+	ErrorKindUnknown:         "unknown",
+	ErrorKindParser:          "parser",
+	ErrorKindBinder:          "binder",
+	ErrorKindRuntime:         "runtime",
+	ErrorKindInterrupted:     "interrupted",
+	ErrorKindTimeout:         "timeout",
+	ErrorKindConversion:      "conversion",
+	ErrorKindClosed:          "closed",
+	ErrorKindConnection:      "connection",
+	ErrorKindCatalog:         "catalog",
+	ErrorKindConstraint:      "constraint",
+	ErrorKindInvalidArgument: "invalid argument",
+}
+
+// String returns the name of the ErrorKind.
+func (kind ErrorKind) String() string {
+	if name, ok := errorKindNames[kind]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorKind(%d)", int(kind))
+}
+
+// Error is the error returned by the operations that fail in Kuzu or while
+// converting values between Go and Kuzu.
+// Use errors.As to inspect its Kind, or errors.Is with one of the sentinel
+// errors such as ErrParser to test for a given kind.
+type Error struct {
+	// Kind classifies the error.
+	Kind ErrorKind
+	// Message is the original error message.
+	Message string
+	// Query is the text of the query that failed, if any.
+	Query string
+	// Err is the underlying error, if any.
+	Err error
+}
+
+// The sentinel errors matching every Error of the corresponding kind with
+// errors.Is.
+var (
+	ErrParser          = &Error{Kind: ErrorKindParser}
+	ErrBinder          = &Error{Kind: ErrorKindBinder}
+	ErrRuntime         = &Error{Kind: ErrorKindRuntime}
+	ErrInterrupted     = &Error{Kind: ErrorKindInterrupted}
+	ErrTimeout         = &Error{Kind: ErrorKindTimeout}
+	ErrConversion      = &Error{Kind: ErrorKindConversion}
+	ErrClosed          = &Error{Kind: ErrorKindClosed}
+	ErrConnection      = &Error{Kind: ErrorKindConnection}
+	ErrCatalog         = &Error{Kind: ErrorKindCatalog}
+	ErrConstraint      = &Error{Kind: ErrorKindConstraint}
+	ErrInvalidArgument = &Error{Kind: ErrorKindInvalidArgument}
+)
+
+// Error returns the message of the Error.
+func (err *Error) Error() string {
+	if err.Message == "" {
+		return "kuzu: " + err.Kind.String() + " error"
+	}
+	return err.Message
+}
+
+// Unwrap returns the underlying error, if any.
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Is returns true if the target is a sentinel error of the same kind as the
+// Error, such as ErrParser for a parser error.
+func (err *Error) Is(target error) bool {
+	sentinel, ok := target.(*Error)
+	if !ok || sentinel.Message != "" || sentinel.Query != "" || sentinel.Err != nil {
+		return false
+	}
+	return sentinel.Kind == err.Kind
+}
+
+// newError returns an Error of the given kind whose message is formatted as
+// in fmt.Errorf. An error wrapped with %w becomes the underlying error.
+func newError(kind ErrorKind, format string, args ...any) *Error {
+	wrapped := fmt.Errorf(format, args...)
+	return &Error{Kind: kind, Message: wrapped.Error(), Err: errors.Unwrap(wrapped)}
+}
+
+// wrapError returns an Error wrapping err, of the kind of err if it is an
+// Error and of ErrorKindRuntime otherwise, whose message is the specified
+// message followed by the message of err.
+func wrapError(err error, message string) *Error {
+	kind := ErrorKindRuntime
+	var kuzuErr *Error
+	if errors.As(err, &kuzuErr) {
+		kind = kuzuErr.Kind
+	}
+	return newError(kind, "%s: %w", message, err)
+}
+
+// closedError returns the Error reported when the named object is used after
+// it has been closed.
+func closedError(object string) *Error {
+	return &Error{Kind: ErrorKindClosed, Message: "the " + object + " is closed"}
+}
+
+// errorMessagePrefixes maps the prefixes of the messages of the exceptions
+// raised by Kuzu to the kinds of errors.
+var errorMessagePrefixes = []struct {
+	prefix string
+	kind   ErrorKind
+}{
+	{"Parser exception:", ErrorKindParser},
+	{"Binder exception:", ErrorKindBinder},
+	{"Catalog exception:", ErrorKindCatalog},
+	{"Conversion exception:", ErrorKindConversion},
+	{"Connection exception:", ErrorKindConnection},
+	{"Interrupted", ErrorKindInterrupted},
+	{"Runtime exception:", ErrorKindRuntime},
+	{"Copy exception:", ErrorKindRuntime},
+}
+
+// newQueryError returns an Error for the message of an exception raised by
+// Kuzu while preparing or executing the query, classified by the message.
+func newQueryError(message string, query string) *Error {
+	kind := ErrorKindRuntime
+	for _, entry := range errorMessagePrefixes {
+		if strings.HasPrefix(message, entry.prefix) {
+			kind = entry.kind
+			break
+		}
+	}
+	if kind == ErrorKindRuntime && strings.Contains(message, "violates the") && strings.Contains(message, "constraint") {
+		kind = ErrorKindConstraint
+	}
+	return &Error{Kind: kind, Message: message, Query: query}
+}
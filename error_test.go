@@ -0,0 +1,86 @@
+package kuzu
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorKinds(t *testing.T) {
+	db, err := OpenInMemoryDatabase(DefaultSystemConfig())
+	assert.Nil(t, err)
+	defer db.Close()
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	defer conn.Close()
+	_, err = conn.Query("CREATE NODE TABLE person(id INT64, PRIMARY KEY(id));")
+	assert.Nil(t, err)
+	_, err = conn.Query("CREATE (:person {id: 1});")
+	assert.Nil(t, err)
+	tests := []struct {
+		query    string
+		sentinel error
+		kind     ErrorKind
+	}{
+		{"MATC (n) RETURN n;", ErrParser, ErrorKindParser},
+		{"MATCH (n:nope) RETURN n;", ErrBinder, ErrorKindBinder},
+		{"RETURN CAST('abc' AS INT64);", ErrConversion, ErrorKindConversion},
+		{"RETURN 1/0;", ErrRuntime, ErrorKindRuntime},
+		{"CREATE (:person {id: 1});", ErrConstraint, ErrorKindConstraint},
+		{"CREATE (:person {id: NULL});", ErrConstraint, ErrorKindConstraint},
+	}
+	for _, test := range tests {
+		_, err := conn.Query(test.query)
+		assert.NotNil(t, err, test.query)
+		assert.True(t, errors.Is(err, test.sentinel), test.query)
+		var kuzuErr *Error
+		assert.True(t, errors.As(err, &kuzuErr), test.query)
+		assert.Equal(t, test.kind, kuzuErr.Kind, test.query)
+		assert.Equal(t, test.query, kuzuErr.Query)
+		assert.Equal(t, err.Error(), kuzuErr.Message)
+	}
+	_, err = conn.Query("RETURN 1/0;")
+	assert.False(t, errors.Is(err, ErrParser))
+	assert.False(t, errors.Is(err, &Error{Kind: ErrorKindRuntime, Message: "other"}))
+}
+
+func TestErrorPreparedStatement(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	query := "MATCH (a:nope) WHERE a.ID = $1 RETURN a;"
+	_, err := conn.Prepare(query)
+	assert.True(t, errors.Is(err, ErrBinder))
+	var kuzuErr *Error
+	assert.True(t, errors.As(err, &kuzuErr))
+	assert.Equal(t, query, kuzuErr.Query)
+	query = "MATCH (a:person) WHERE a.ID = $1 RETURN a.fName;"
+	stmt, err := conn.Prepare(query)
+	assert.Nil(t, err)
+	_, err = conn.Execute(stmt, map[string]any{"1": struct{}{}})
+	assert.True(t, errors.Is(err, ErrConversion))
+	assert.Contains(t, err.Error(), "parameter \"1\"")
+	stmt.Close()
+}
+
+func TestErrorContext(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := conn.QueryContext(ctx, "RETURN 1;")
+	assert.True(t, errors.Is(err, ErrInterrupted))
+	assert.True(t, errors.Is(err, context.Canceled))
+	ctx, cancel = context.WithDeadline(context.Background(), time.Unix(0, 0))
+	defer cancel()
+	_, err = conn.QueryContext(ctx, "RETURN 1;")
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestErrorKindString(t *testing.T) {
+	assert.Equal(t, "constraint", ErrorKindConstraint.String())
+	assert.Equal(t, "invalid argument", ErrorKindInvalidArgument.String())
+	assert.Equal(t, "ErrorKind(100)", ErrorKind(100).String())
+	assert.Equal(t, "kuzu: timeout error", ErrTimeout.Error())
+}
@@ -3,28 +3,74 @@ package kuzu
 // #include "kuzu.h"
 // #include <stdlib.h>
 import "C"
-import "fmt"
+
+import (
+	"sync"
+	"time"
+)
 
 // FlatTuple represents a row in the result set of a query.
+// A FlatTuple is owned by its QueryResult and can no longer be used once the
+// QueryResult is closed.
 type FlatTuple struct {
 	cFlatTuple  C.kuzu_flat_tuple
 	queryResult *QueryResult
-	isClosed    bool
+	// cValue holds the value read by the views of the FlatTuple while it is
+	// locked. It is allocated separately, since the C functions may not be
+	// passed pointers into memory holding Go pointers.
+	cValue   *C.kuzu_value
+	isClosed bool
+	mu       sync.Mutex
 }
 
 // Close closes the FlatTuple. Calling this method is optional.
 // The FlatTuple will be closed automatically when it is garbage collected.
+// It is safe to call Close multiple times and concurrently.
 func (tuple *FlatTuple) Close() {
+	tuple.mu.Lock()
+	defer tuple.mu.Unlock()
 	if tuple.isClosed {
 		return
 	}
-	C.kuzu_flat_tuple_destroy(&tuple.cFlatTuple)
+	tuple.queryResult.mu.Lock()
+	defer tuple.queryResult.mu.Unlock()
+	if !tuple.queryResult.isClosed {
+		C.kuzu_flat_tuple_destroy(&tuple.cFlatTuple)
+	}
 	tuple.isClosed = true
 }
 
+// lock locks the FlatTuple and its QueryResult. If either of them is closed,
+// it returns an error matching ErrClosed without holding the locks.
+func (tuple *FlatTuple) lock() error {
+	tuple.mu.Lock()
+	if tuple.isClosed {
+		tuple.mu.Unlock()
+		return closedError("tuple")
+	}
+	tuple.queryResult.mu.Lock()
+	if tuple.queryResult.isClosed {
+		tuple.queryResult.mu.Unlock()
+		tuple.mu.Unlock()
+		return closedError("query result")
+	}
+	return nil
+}
+
+// unlock unlocks the FlatTuple and its QueryResult.
+func (tuple *FlatTuple) unlock() {
+	tuple.queryResult.mu.Unlock()
+	tuple.mu.Unlock()
+}
+
 // GetAsString returns the string representation of the FlatTuple.
 // The string representation contains the values of the tuple separated by vertical bars.
+// It returns an empty string if the FlatTuple or its QueryResult is closed.
 func (tuple *FlatTuple) GetAsString() string {
+	if err := tuple.lock(); err != nil {
+		return ""
+	}
+	defer tuple.unlock()
 	cString := C.kuzu_flat_tuple_to_string(&tuple.cFlatTuple)
 	defer C.kuzu_destroy_string(cString)
 	return C.GoString(cString)
@@ -45,7 +91,7 @@ func (tuple *FlatTuple) GetAsSlice() ([]any, error) {
 		values = append(values, value)
 	}
 	if len(errors) > 0 {
-		return values, fmt.Errorf("failed to get values: %v", errors)
+		return values, newError(ErrorKindConversion, "failed to get values: %v", errors)
 	}
 	return values, nil
 }
@@ -67,12 +113,115 @@ func (tuple *FlatTuple) GetAsMap() (map[string]any, error) {
 	return m, err
 }
 
-// GetValue returns the value at the given index in the FlatTuple.
+// GetValue returns the value at the given index in the FlatTuple, converted as
+// configured by the ValueOptions of the connection when the query was executed.
+// NULL values are returned as nil, and so are the NULL elements, fields, map
+// values and properties nested in them, whatever their type.
+// Nested values are converted as a whole; use View or the typed getters such
+// as GetInt64 to read only the needed parts of a value.
 func (tuple *FlatTuple) GetValue(index uint64) (any, error) {
+	if err := tuple.lock(); err != nil {
+		return nil, err
+	}
+	defer tuple.unlock()
 	var cValue C.kuzu_value
 	status := C.kuzu_flat_tuple_get_value(&tuple.cFlatTuple, C.uint64_t(index), &cValue)
 	if status != C.KuzuSuccess {
-		return nil, fmt.Errorf("failed to get value with status: %d", status)
+		return nil, newError(ErrorKindConversion, "failed to get value with status: %d", status)
+	}
+	columnType := LogicalType{}
+	if columnTypes := tuple.queryResult.getColumnTypes(); index < uint64(len(columnTypes)) {
+		columnType = columnTypes[index]
+	}
+	return kuzuValueToGoValue(cValue, columnType, tuple.queryResult.valueOptions)
+}
+
+// IsNull returns true if the value at the given index in the FlatTuple is
+// NULL.
+func (tuple *FlatTuple) IsNull(index uint64) (bool, error) {
+	return tuple.View(index).IsNull()
+}
+
+// GetBool returns the BOOL value at the given index in the FlatTuple without
+// converting it to an interface. It returns an error matching ErrConversion if
+// the value is NULL or of another type. The same applies to the other typed
+// getters.
+func (tuple *FlatTuple) GetBool(index uint64) (bool, error) {
+	return tuple.View(index).Bool()
+}
+
+// GetInt64 returns the integer value at the given index in the FlatTuple as
+// described in Value.Int64.
+func (tuple *FlatTuple) GetInt64(index uint64) (int64, error) {
+	return tuple.View(index).Int64()
+}
+
+// GetFloat64 returns the DOUBLE or FLOAT value at the given index in the
+// FlatTuple.
+func (tuple *FlatTuple) GetFloat64(index uint64) (float64, error) {
+	return tuple.View(index).Float64()
+}
+
+// GetString returns the STRING or UUID value at the given index in the
+// FlatTuple.
+func (tuple *FlatTuple) GetString(index uint64) (string, error) {
+	return tuple.View(index).String()
+}
+
+// GetTime returns the DATE or TIMESTAMP value at the given index in the
+// FlatTuple as described in Value.Time.
+func (tuple *FlatTuple) GetTime(index uint64) (time.Time, error) {
+	return tuple.View(index).Time()
+}
+
+// Scan copies the values of the FlatTuple into the values pointed to by dest.
+// The number of destinations must be the same as the number of columns in the
+// query result. The values are converted to the types of the destinations,
+// e.g. an INT64 can be scanned into an int, a DATE into a time.Time, a STRUCT
+// into a struct or a map, a LIST into a slice and a MAP into a Go map. A
+// BLOB can also be written to a destination implementing io.Writer, such as
+// a *bytes.Buffer or an *os.File. NULL can only be scanned into a pointer, an
+// interface, a map, a slice or a Null, and returns an error otherwise rather
+// than storing the zero value.
+func (tuple *FlatTuple) Scan(dest ...any) error {
+	numColumns := tuple.queryResult.GetNumberOfColumns()
+	if uint64(len(dest)) != numColumns {
+		return newError(ErrorKindConversion, "expected %d destination arguments in Scan, got %d", numColumns, len(dest))
+	}
+	for i, d := range dest {
+		value, err := tuple.GetValue(uint64(i))
+		if err != nil {
+			return err
+		}
+		if err := scanInto(d, value); err != nil {
+			return newError(ErrorKindConversion, "failed to scan column %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ScanStruct copies the values of the FlatTuple into the fields of the struct
+// pointed to by dest. A column is mapped to the field whose `kuzu` tag is the
+// column name, or otherwise to the field whose name matches the column name
+// case-insensitively. Columns without a matching field are ignored, and a tag
+// of "-" excludes a field. The values are converted as in Scan.
+func (tuple *FlatTuple) ScanStruct(dest any) error {
+	structValue, err := structDestination(dest)
+	if err != nil {
+		return err
+	}
+	for i, columnName := range tuple.queryResult.GetColumnNames() {
+		field, ok := lookupStructField(structValue.Type(), columnName)
+		if !ok {
+			continue
+		}
+		value, err := tuple.GetValue(uint64(i))
+		if err != nil {
+			return err
+		}
+		if err := assignValue(fieldByIndex(structValue, field.index), value); err != nil {
+			return newError(ErrorKindConversion, "failed to scan column %q: %w", columnName, err)
+		}
 	}
-	return kuzuValueToGoValue(cValue)
+	return nil
 }
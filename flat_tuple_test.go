@@ -1,7 +1,10 @@
 package kuzu
 
 import (
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -88,3 +91,409 @@ func TestGetValue(t *testing.T) {
 	assert.Equal(t, int64(35), value)
 	tuple.Close()
 }
+
+func TestTupleScan(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	query := "MATCH (a:person) WHERE a.ID = 0 RETURN a.fName, a.age, a.birthdate, a.courseScoresPerTerm, a.grades, a.eyeSight;"
+	res, err := conn.Query(query)
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	var name string
+	var age int
+	var birthdate time.Time
+	var courseScoresPerTerm [][]int32
+	var grades [4]int64
+	var eyeSight *float64
+	err = tuple.Scan(&name, &age, &birthdate, &courseScoresPerTerm, &grades, &eyeSight)
+	assert.Nil(t, err)
+	assert.Equal(t, "Alice", name)
+	assert.Equal(t, 35, age)
+	assert.Equal(t, 1900, birthdate.Year())
+	assert.Equal(t, [][]int32{{10, 8}, {6, 7, 8}}, courseScoresPerTerm)
+	assert.Equal(t, [4]int64{96, 54, 86, 92}, grades)
+	assert.InDelta(t, 5.0, *eyeSight, floatEpsilon)
+	tuple.Close()
+	res.Close()
+}
+
+func TestTupleScanErrors(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("RETURN 'Alice', CAST(300, 'INT64');")
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	var name string
+	var small int8
+	err = tuple.Scan(&name)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "expected 2 destination arguments")
+	assert.ErrorIs(t, err, ErrConversion)
+	err = tuple.Scan(name, &small)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "non-nil pointer")
+	err = tuple.Scan(&name, &small)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "overflows int8")
+	var wrong bool
+	err = tuple.Scan(&wrong, &name)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "cannot assign string to bool")
+	tuple.Close()
+	res.Close()
+}
+
+func TestTupleScanStruct(t *testing.T) {
+	type stock struct {
+		Price  []int   `kuzu:"price"`
+		Volume float64 `kuzu:"volume"`
+	}
+	type state struct {
+		Revenue  int16
+		Location []string
+		Stock    stock
+	}
+	type organisation struct {
+		Name    string         `kuzu:"o.name"`
+		State   state          `kuzu:"o.state"`
+		Ignored string         `kuzu:"-"`
+		Extra   map[string]any `kuzu:"extra"`
+		Missing string
+	}
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (o:organisation) WHERE o.ID = 1 RETURN o.name, o.state, {a: 1} AS extra, 'x' AS ignored;")
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	var org organisation
+	err = tuple.ScanStruct(&org)
+	assert.Nil(t, err)
+	assert.Equal(t, "ABFsUni", org.Name)
+	assert.Equal(t, int16(138), org.State.Revenue)
+	assert.Equal(t, []string{"'toronto'", "'montr,eal'"}, org.State.Location)
+	assert.Equal(t, []int{96, 56}, org.State.Stock.Price)
+	assert.Equal(t, float64(1000), org.State.Stock.Volume)
+	assert.Equal(t, map[string]any{"a": int64(1)}, org.Extra)
+	assert.Equal(t, "", org.Ignored)
+	assert.Equal(t, "", org.Missing)
+	err = tuple.ScanStruct(org)
+	assert.NotNil(t, err)
+	tuple.Close()
+	res.Close()
+}
+
+func TestTupleScanStructUnexportedEmbeddedPointer(t *testing.T) {
+	type inner struct {
+		Name string
+	}
+	type person struct {
+		*inner
+		Age int64
+	}
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("RETURN 'Alice' AS name, CAST(30, 'INT64') AS age;")
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	var p person
+	assert.NotPanics(t, func() {
+		err = tuple.ScanStruct(&p)
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, p.inner)
+	assert.Equal(t, int64(30), p.Age)
+	tuple.Close()
+	res.Close()
+}
+
+type scanNamed struct {
+	Name string
+	Age  int64
+}
+
+type scanTitled struct {
+	Name string
+}
+
+// ScanSelf is exported so that the fields promoted through the pointer to
+// itself are not skipped.
+type ScanSelf struct {
+	*ScanSelf
+	Name string
+}
+
+func TestTupleScanStructEmbeddedDominance(t *testing.T) {
+	type person struct {
+		scanNamed
+		scanTitled
+		Name string
+	}
+	type ambiguous struct {
+		scanNamed
+		scanTitled
+	}
+	assert.Equal(t, []structField{{name: "Age", index: []int{0, 1}}, {name: "Name", index: []int{2}}}, structFieldsOf(reflect.TypeOf(person{})))
+	assert.Equal(t, []structField{{name: "Age", index: []int{0, 1}}}, structFieldsOf(reflect.TypeOf(ambiguous{})))
+	assert.Equal(t, []structField{{name: "Name", index: []int{1}}}, structFieldsOf(reflect.TypeOf(ScanSelf{})))
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("RETURN 'Alice' AS name, CAST(30, 'INT64') AS age;")
+	assert.Nil(t, err)
+	defer res.Close()
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	var p person
+	assert.Nil(t, tuple.ScanStruct(&p))
+	assert.Equal(t, "Alice", p.Name)
+	assert.Equal(t, "", p.scanNamed.Name)
+	assert.Equal(t, int64(30), p.Age)
+	var self ScanSelf
+	assert.Nil(t, tuple.ScanStruct(&self))
+	assert.Equal(t, "Alice", self.Name)
+}
+
+func TestTupleScanMap(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (m:movies) WHERE m.length = 2544 RETURN m.audience;")
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	var audience map[string]int
+	err = tuple.Scan(&audience)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"audience1": 33}, audience)
+	tuple.Close()
+	res.Close()
+}
+
+func TestTupleScanGoMap(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, _ := OpenConnection(db)
+	defer conn.Close()
+	conn.SetValueOptions(ValueOptions{GoMaps: true})
+	res, err := conn.Query("RETURN map([1, 2], [10, 20]) AS a, map([3], [30]) AS b;")
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	var goMap map[int]uint8
+	var items []MapItem
+	err = tuple.Scan(&goMap, &items)
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]uint8{1: 10, 2: 20}, goMap)
+	assert.Equal(t, []MapItem{{Key: int64(3), Value: int64(30)}}, items)
+	tuple.Close()
+	res.Close()
+}
+
+func TestTupleScanUnion(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (m:movies) WHERE m.length = 2544 RETURN m.grade AS a, m.grade AS b;")
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	var grade float64
+	var union Union
+	err = tuple.Scan(&grade, &union)
+	assert.Nil(t, err)
+	assert.InDelta(t, 8.989, grade, floatEpsilon)
+	assert.Equal(t, "grade1", union.Tag)
+	tuple.Close()
+	res.Close()
+}
+
+func TestTupleTypedGetters(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	query := "MATCH (a:person) WHERE a.ID = 0 RETURN a.fName, a.age, a.eyeSight, a.height, a.isStudent, a.birthdate, a.registerTime, a.u, CAST(NULL AS INT64);"
+	res, err := conn.Query(query)
+	assert.Nil(t, err)
+	defer res.Close()
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	defer tuple.Close()
+	name, err := tuple.GetString(0)
+	assert.Nil(t, err)
+	assert.Equal(t, "Alice", name)
+	age, err := tuple.GetInt64(1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(35), age)
+	eyeSight, err := tuple.GetFloat64(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 5.0, eyeSight)
+	height, err := tuple.GetFloat64(3)
+	assert.Nil(t, err)
+	assert.InDelta(t, 1.731, height, floatEpsilon)
+	isStudent, err := tuple.GetBool(4)
+	assert.Nil(t, err)
+	assert.True(t, isStudent)
+	birthdate, err := tuple.GetTime(5)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), birthdate)
+	registerTime, err := tuple.GetTime(6)
+	assert.Nil(t, err)
+	assert.True(t, time.Date(2011, 8, 20, 11, 25, 30, 0, time.UTC).Equal(registerTime))
+	u, err := tuple.GetString(7)
+	assert.Nil(t, err)
+	assert.Equal(t, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", u)
+	isNull, err := tuple.IsNull(8)
+	assert.Nil(t, err)
+	assert.True(t, isNull)
+	isNull, err = tuple.IsNull(1)
+	assert.Nil(t, err)
+	assert.False(t, isNull)
+
+	_, err = tuple.GetInt64(8)
+	assert.True(t, errors.Is(err, ErrConversion))
+	_, err = tuple.GetInt64(0)
+	assert.True(t, errors.Is(err, ErrConversion))
+	_, err = tuple.GetString(1)
+	assert.True(t, errors.Is(err, ErrConversion))
+	_, err = tuple.GetTime(0)
+	assert.True(t, errors.Is(err, ErrConversion))
+	_, err = tuple.GetInt64(9)
+	assert.True(t, errors.Is(err, ErrConversion))
+}
+
+func TestTupleGetInt64Widens(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("RETURN CAST(-3 AS INT8), CAST(200 AS UINT8), CAST(4000000000 AS UINT32), CAST(18446744073709551615 AS UINT64);")
+	assert.Nil(t, err)
+	defer res.Close()
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	defer tuple.Close()
+	value, err := tuple.GetInt64(0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-3), value)
+	value, err = tuple.GetInt64(1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(200), value)
+	value, err = tuple.GetInt64(2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4000000000), value)
+	_, err = tuple.GetInt64(3)
+	assert.True(t, errors.Is(err, ErrConversion))
+}
+
+func TestTupleView(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (o:organisation) WHERE o.ID = 4 RETURN o.state, o, [[1, 2], [3]] AS l, CAST(NULL AS STRUCT(a INT64)) AS n;")
+	assert.Nil(t, err)
+	defer res.Close()
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	defer tuple.Close()
+
+	state := tuple.View(0)
+	typeID, err := state.Type()
+	assert.Nil(t, err)
+	assert.Equal(t, TypeStruct, typeID)
+	length, err := state.Len()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, length)
+	revenue, err := state.Field("revenue").Int64()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(152), revenue)
+	location, err := state.Field("location").Index(0).String()
+	assert.Nil(t, err)
+	assert.Equal(t, `"vanco,uver north area"`, location)
+	prices := state.Field("stock").Field("price")
+	length, err = prices.Len()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, length)
+	price, err := prices.Index(2).Int64()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(671), price)
+	stock, err := state.Field("stock").Any()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"price": []any{int64(15), int64(78), int64(671)}, "volume": int64(432)}, stock)
+
+	name, err := tuple.View(1).Field("name").String()
+	assert.Nil(t, err)
+	assert.Equal(t, "CsWork", name)
+	info, err := tuple.View(1).Field("info").Any()
+	assert.Nil(t, err)
+	assert.Equal(t, Union{Tag: "note", Value: "abcd"}, info)
+
+	element, err := tuple.View(2).Index(1).Index(0).Int64()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), element)
+
+	isNull, err := tuple.View(3).Field("a").IsNull()
+	assert.Nil(t, err)
+	assert.True(t, isNull)
+	_, err = tuple.View(3).Field("a").Int64()
+	assert.True(t, errors.Is(err, ErrConversion))
+
+	_, err = state.Field("missing").Int64()
+	assert.True(t, errors.Is(err, ErrConversion))
+	_, err = prices.Index(3).Int64()
+	assert.True(t, errors.Is(err, ErrConversion))
+	_, err = state.Index(0).Int64()
+	assert.True(t, errors.Is(err, ErrConversion))
+
+	tuple.Close()
+	_, err = state.Field("revenue").Int64()
+	assert.True(t, errors.Is(err, ErrClosed))
+}
+
+func TestTupleTypedGettersDoNotAllocate(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) WHERE a.ID = 0 RETURN a.age, a.eyeSight, a.isStudent, a.registerTime;")
+	assert.Nil(t, err)
+	defer res.Close()
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	defer tuple.Close()
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = tuple.GetInt64(0)
+		_, _ = tuple.GetFloat64(1)
+		_, _ = tuple.GetBool(2)
+		_, _ = tuple.GetTime(3)
+		_, _ = tuple.IsNull(0)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func TestTupleScanNull(t *testing.T) {
+	db, err := OpenInMemoryDatabase(DefaultSystemConfig())
+	assert.Nil(t, err)
+	defer db.Close()
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	defer conn.Close()
+	res, err := conn.Query("CREATE NODE TABLE user(id INT64, name STRING, age INT64, PRIMARY KEY(id));")
+	assert.Nil(t, err)
+	res.Close()
+	res, err = conn.Query("CREATE (:user {id: 1, name: 'Adam'});")
+	assert.Nil(t, err)
+	res.Close()
+
+	res, err = conn.Query("MATCH (u:user) RETURN u, u.name, u.age, [u.age, 1] AS ages;")
+	assert.Nil(t, err)
+	defer res.Close()
+	next, err := res.Next()
+	assert.Nil(t, err)
+	node, err := next.GetValue(0)
+	assert.Nil(t, err)
+	assert.Contains(t, node.(Node).Properties, "age")
+	assert.Nil(t, node.(Node).Properties["age"])
+
+	var user struct {
+		Name string
+		Age  Null[int64]
+	}
+	var name Null[string]
+	var age Null[int64]
+	var ages []Null[int]
+	assert.Nil(t, next.Scan(&user, &name, &age, &ages))
+	assert.Equal(t, "Adam", user.Name)
+	assert.False(t, user.Age.Valid)
+	assert.Equal(t, Null[string]{V: "Adam", Valid: true}, name)
+	assert.Equal(t, Null[int64]{}, age)
+	assert.Equal(t, []Null[int]{{}, {V: 1, Valid: true}}, ages)
+
+	// NULL is not scanned as the zero value of types that cannot hold it.
+	var plainAge int64
+	var ignored any
+	err = next.Scan(&ignored, &ignored, &plainAge, &ignored)
+	assert.True(t, errors.Is(err, ErrConversion))
+}
@@ -0,0 +1,100 @@
+package kuzu
+
+// #include "kuzu.h"
+import "C"
+
+import (
+	"math"
+	"time"
+)
+
+// Interval represents a Kuzu INTERVAL value. The months, days and
+// microseconds are kept separately, because the length of a month or a day
+// depends on the date the interval is added to.
+// Intervals are returned for INTERVAL values, unless
+// ValueOptions.IntervalAsDuration is set, and can be passed as parameters. A
+// value can be converted to a time.Duration by scanning it into a
+// time.Duration or with Duration.
+type Interval struct {
+	Months int32
+	Days   int32
+	Micros int64
+}
+
+// IntervalFromDuration returns the Interval of the specified duration,
+// truncated to the microsecond.
+func IntervalFromDuration(duration time.Duration) Interval {
+	return Interval{Micros: duration.Microseconds()}
+}
+
+// Duration returns the interval as a time.Duration, counting a month as 30
+// days and a day as 24 hours. The result is only exact if Months and Days are
+// zero. An interval longer than the maximum time.Duration, about 292 years,
+// returns the maximum or minimum time.Duration.
+func (interval Interval) Duration() time.Duration {
+	return kuzuIntervalToDuration(interval.toKuzuInterval())
+}
+
+// Negate returns the opposite of the interval.
+func (interval Interval) Negate() Interval {
+	return Interval{Months: -interval.Months, Days: -interval.Days, Micros: -interval.Micros}
+}
+
+// AddTo returns the time t plus the interval, computed in the location of t as
+// Kuzu does: the months are added first, keeping the day of the month unless
+// the resulting month is shorter, in which case the last day of that month is
+// used. The days are then added as calendar days, and the microseconds as
+// elapsed time. For example, 1 month after January 31 is the last day of
+// February.
+func (interval Interval) AddTo(t time.Time) time.Time {
+	if interval.Months != 0 {
+		year, month, day := t.Date()
+		hour, minute, second := t.Clock()
+		firstOfMonth := time.Date(year, month+time.Month(interval.Months), 1, 0, 0, 0, 0, t.Location())
+		if lastDay := daysIn(firstOfMonth.Year(), firstOfMonth.Month()); day > lastDay {
+			day = lastDay
+		}
+		t = time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, hour, minute, second, t.Nanosecond(), t.Location())
+	}
+	if interval.Days != 0 {
+		t = t.AddDate(0, 0, int(interval.Days))
+	}
+	// The microseconds are added in steps that fit in a time.Duration.
+	micros := interval.Micros
+	for micros > maxDurationMicros {
+		t = t.Add(time.Duration(maxDurationMicros) * time.Microsecond)
+		micros -= maxDurationMicros
+	}
+	for micros < -maxDurationMicros {
+		t = t.Add(-time.Duration(maxDurationMicros) * time.Microsecond)
+		micros += maxDurationMicros
+	}
+	return t.Add(time.Duration(micros) * time.Microsecond)
+}
+
+// maxDurationMicros is the largest number of microseconds that a
+// time.Duration can hold.
+const maxDurationMicros = math.MaxInt64 / int64(time.Microsecond)
+
+// daysIn returns the number of days in the month of the year.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// toKuzuInterval converts the Interval to a kuzu_interval_t.
+func (interval Interval) toKuzuInterval() C.kuzu_interval_t {
+	return C.kuzu_interval_t{
+		months: C.int32_t(interval.Months),
+		days:   C.int32_t(interval.Days),
+		micros: C.int64_t(interval.Micros),
+	}
+}
+
+// kuzuIntervalToInterval converts a kuzu_interval_t to an Interval.
+func kuzuIntervalToInterval(cKuzuInterval C.kuzu_interval_t) Interval {
+	return Interval{
+		Months: int32(cKuzuInterval.months),
+		Days:   int32(cKuzuInterval.days),
+		Micros: int64(cKuzuInterval.micros),
+	}
+}
@@ -0,0 +1,51 @@
+package kuzu
+
+import (
+	"sync"
+	"unsafe"
+	"weak"
+)
+
+// children tracks the live objects derived from a parent object, such as the
+// connections of a database, so that they can be closed before the parent.
+// The children are referenced weakly: a child that is no longer reachable can
+// still be garbage collected and closed by its finalizer.
+// The children are keyed by their address, which does not change while they
+// are alive. A weak pointer could not serve as the key, since the weak pointer
+// made by the finalizer of a child is not equal to the one made before.
+type children[T any] struct {
+	mu    sync.Mutex
+	items map[uintptr]weak.Pointer[T]
+}
+
+// add starts tracking the child.
+func (c *children[T]) add(child *T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.items == nil {
+		c.items = make(map[uintptr]weak.Pointer[T])
+	}
+	c.items[uintptr(unsafe.Pointer(child))] = weak.Make(child)
+}
+
+// remove stops tracking the child.
+func (c *children[T]) remove(child *T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, uintptr(unsafe.Pointer(child)))
+}
+
+// drain stops tracking all the children and returns the ones that are still
+// reachable. The others are about to be closed by their finalizers.
+func (c *children[T]) drain() []*T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	live := make([]*T, 0, len(c.items))
+	for _, item := range c.items {
+		if child := item.Value(); child != nil {
+			live = append(live, child)
+		}
+	}
+	c.items = nil
+	return live
+}
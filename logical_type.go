@@ -0,0 +1,437 @@
+package kuzu
+
+// #include "kuzu.h"
+// #include <stdlib.h>
+//
+// static void kuzu_go_release_arrow_schema(struct ArrowSchema* schema) {
+//     if (schema->release != NULL) {
+//         schema->release(schema);
+//     }
+// }
+import "C"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// TypeID represents the identifier of a logical data type in Kuzu.
+type TypeID int
+
+// The logical data types supported by Kuzu.
+const (
+	TypeAny          TypeID = C.KUZU_ANY
+	TypeNode         TypeID = C.KUZU_NODE
+	TypeRel          TypeID = C.KUZU_REL
+	TypeRecursiveRel TypeID = C.KUZU_RECURSIVE_REL
+	TypeSerial       TypeID = C.KUZU_SERIAL
+	TypeBool         TypeID = C.KUZU_BOOL
+	TypeInt64        TypeID = C.KUZU_INT64
+	TypeInt32        TypeID = C.KUZU_INT32
+	TypeInt16        TypeID = C.KUZU_INT16
+	TypeInt8         TypeID = C.KUZU_INT8
+	TypeUint64       TypeID = C.KUZU_UINT64
+	TypeUint32       TypeID = C.KUZU_UINT32
+	TypeUint16       TypeID = C.KUZU_UINT16
+	TypeUint8        TypeID = C.KUZU_UINT8
+	TypeInt128       TypeID = C.KUZU_INT128
+	TypeDouble       TypeID = C.KUZU_DOUBLE
+	TypeFloat        TypeID = C.KUZU_FLOAT
+	TypeDate         TypeID = C.KUZU_DATE
+	TypeTimestamp    TypeID = C.KUZU_TIMESTAMP
+	TypeTimestampSec TypeID = C.KUZU_TIMESTAMP_SEC
+	TypeTimestampMs  TypeID = C.KUZU_TIMESTAMP_MS
+	TypeTimestampNs  TypeID = C.KUZU_TIMESTAMP_NS
+	TypeTimestampTz  TypeID = C.KUZU_TIMESTAMP_TZ
+	TypeInterval     TypeID = C.KUZU_INTERVAL
+	TypeDecimal      TypeID = C.KUZU_DECIMAL
+	TypeInternalID   TypeID = C.KUZU_INTERNAL_ID
+	TypeString       TypeID = C.KUZU_STRING
+	TypeBlob         TypeID = C.KUZU_BLOB
+	TypeList         TypeID = C.KUZU_LIST
+	TypeArray        TypeID = C.KUZU_ARRAY
+	TypeStruct       TypeID = C.KUZU_STRUCT
+	TypeMap          TypeID = C.KUZU_MAP
+	TypeUnion        TypeID = C.KUZU_UNION
+	TypePointer      TypeID = C.KUZU_POINTER
+	TypeUUID         TypeID = C.KUZU_UUID
+)
+
+var typeIDNames = map[TypeID]string{
+	TypeAny:          "ANY",
+	TypeNode:         "NODE",
+	TypeRel:          "REL",
+	TypeRecursiveRel: "RECURSIVE_REL",
+	TypeSerial:       "SERIAL",
+	TypeBool:         "BOOL",
+	TypeInt64:        "INT64",
+	TypeInt32:        "INT32",
+	TypeInt16:        "INT16",
+	TypeInt8:         "INT8",
+	TypeUint64:       "UINT64",
+	TypeUint32:       "UINT32",
+	TypeUint16:       "UINT16",
+	TypeUint8:        "UINT8",
+	TypeInt128:       "INT128",
+	TypeDouble:       "DOUBLE",
+	TypeFloat:        "FLOAT",
+	TypeDate:         "DATE",
+	TypeTimestamp:    "TIMESTAMP",
+	TypeTimestampSec: "TIMESTAMP_SEC",
+	TypeTimestampMs:  "TIMESTAMP_MS",
+	TypeTimestampNs:  "TIMESTAMP_NS",
+	TypeTimestampTz:  "TIMESTAMP_TZ",
+	TypeInterval:     "INTERVAL",
+	TypeDecimal:      "DECIMAL",
+	TypeInternalID:   "INTERNAL_ID",
+	TypeString:       "STRING",
+	TypeBlob:         "BLOB",
+	TypeList:         "LIST",
+	TypeArray:        "ARRAY",
+	TypeStruct:       "STRUCT",
+	TypeMap:          "MAP",
+	TypeUnion:        "UNION",
+	TypePointer:      "POINTER",
+	TypeUUID:         "UUID",
+}
+
+// String returns the name of the type as used in Cypher, e.g. "INT64".
+func (id TypeID) String() string {
+	if name, ok := typeIDNames[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("TypeID(%d)", int(id))
+}
+
+// LogicalType represents a Kuzu data type, including the types nested in it.
+// Children holds the element type for LIST and ARRAY, the key and value types
+// for MAP, and the field types for STRUCT and UNION, whose field names are
+// held in FieldNames in the same order. For NODE, REL and RECURSIVE_REL, they
+// hold the types of the fields these values are exported to Arrow with, e.g.
+// the _ID and _LABEL of a node followed by its properties.
+// ArrayLength is the number of elements of an ARRAY.
+// Precision and Scale are set for DECIMAL.
+type LogicalType struct {
+	ID          TypeID
+	Children    []LogicalType
+	FieldNames  []string
+	ArrayLength uint64
+	Precision   uint32
+	Scale       uint32
+}
+
+// String returns the Cypher representation of the type, e.g.
+// "STRUCT(a INT64, b STRING[])".
+func (logicalType LogicalType) String() string {
+	switch logicalType.ID {
+	case TypeList:
+		if len(logicalType.Children) == 1 {
+			return logicalType.Children[0].String() + "[]"
+		}
+	case TypeArray:
+		if len(logicalType.Children) == 1 {
+			return fmt.Sprintf("%s[%d]", logicalType.Children[0].String(), logicalType.ArrayLength)
+		}
+	case TypeMap:
+		if len(logicalType.Children) == 2 {
+			return fmt.Sprintf("MAP(%s, %s)", logicalType.Children[0].String(), logicalType.Children[1].String())
+		}
+	case TypeStruct, TypeUnion:
+		if len(logicalType.Children) > 0 && len(logicalType.Children) == len(logicalType.FieldNames) {
+			fields := make([]string, len(logicalType.Children))
+			for i, child := range logicalType.Children {
+				fields[i] = logicalType.FieldNames[i] + " " + child.String()
+			}
+			return fmt.Sprintf("%s(%s)", logicalType.ID.String(), strings.Join(fields, ", "))
+		}
+	case TypeDecimal:
+		if logicalType.Precision > 0 {
+			return fmt.Sprintf("DECIMAL(%d, %d)", logicalType.Precision, logicalType.Scale)
+		}
+	}
+	return logicalType.ID.String()
+}
+
+// child returns the type of the child at the index, or the zero LogicalType if
+// it is unknown.
+func (logicalType LogicalType) child(index int) LogicalType {
+	if index < len(logicalType.Children) {
+		return logicalType.Children[index]
+	}
+	return LogicalType{}
+}
+
+// field returns the type of the named field, or the zero LogicalType if it is
+// unknown.
+func (logicalType LogicalType) field(name string) LogicalType {
+	for i, fieldName := range logicalType.FieldNames {
+		if fieldName == name {
+			return logicalType.child(i)
+		}
+	}
+	return LogicalType{}
+}
+
+// kuzuLogicalTypeToGo converts a kuzu_logical_type to a LogicalType in Go.
+// The C API only exposes the type ID and the length of ARRAY types, so the
+// nested types are not populated.
+func kuzuLogicalTypeToGo(cLogicalType *C.kuzu_logical_type) LogicalType {
+	logicalType := LogicalType{ID: TypeID(C.kuzu_data_type_get_id(cLogicalType))}
+	if logicalType.ID == TypeArray {
+		var numElements C.uint64_t
+		if C.kuzu_data_type_get_num_elements_in_array(cLogicalType, &numElements) == C.KuzuSuccess {
+			logicalType.ArrayLength = uint64(numElements)
+		}
+	}
+	return logicalType
+}
+
+// GetColumnTypes returns the data types of the columns of the QueryResult.
+// The nested types are derived from the Arrow schema of the result. If the
+// schema is not available for the result, only the top-level type IDs are
+// populated.
+func (queryResult *QueryResult) GetColumnTypes() []LogicalType {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	return queryResult.getColumnTypes()
+}
+
+// getColumnTypes returns the data types of the columns of the QueryResult,
+// loading them on the first call. The caller must hold the lock of the
+// QueryResult.
+func (queryResult *QueryResult) getColumnTypes() []LogicalType {
+	if queryResult.columnTypes != nil {
+		return queryResult.columnTypes
+	}
+	if queryResult.isClosed {
+		return nil
+	}
+	numColumns := uint64(C.kuzu_query_result_get_num_columns(&queryResult.cQueryResult))
+	columnTypes := make([]LogicalType, 0, numColumns)
+	for i := uint64(0); i < numColumns; i++ {
+		var cLogicalType C.kuzu_logical_type
+		C.kuzu_query_result_get_column_data_type(&queryResult.cQueryResult, C.uint64_t(i), &cLogicalType)
+		columnTypes = append(columnTypes, kuzuLogicalTypeToGo(&cLogicalType))
+		C.kuzu_data_type_destroy(&cLogicalType)
+	}
+	var schema C.struct_ArrowSchema
+	status := C.kuzu_query_result_get_arrow_schema(&queryResult.cQueryResult, &schema)
+	if status == C.KuzuSuccess {
+		defer C.kuzu_go_release_arrow_schema(&schema)
+		children := arrowSchemaChildren(&schema)
+		if len(children) == len(columnTypes) {
+			for i, child := range children {
+				columnTypes[i] = arrowSchemaToLogicalType(child, columnTypes[i].ID)
+			}
+		}
+	}
+	queryResult.columnTypes = columnTypes
+	return columnTypes
+}
+
+// arrowTypeIDs maps the IDs of the types that Kuzu exports to Arrow with the
+// format of another type to the ID that arrowFormatToTypeID returns for them.
+var arrowTypeIDs = map[TypeID]TypeID{
+	TypeSerial:       TypeInt64,
+	TypeUUID:         TypeString,
+	TypeInternalID:   TypeStruct,
+	TypeNode:         TypeStruct,
+	TypeRel:          TypeStruct,
+	TypeRecursiveRel: TypeStruct,
+}
+
+// arrowSchemaChildren returns the children of an ArrowSchema as a slice.
+func arrowSchemaChildren(schema *C.struct_ArrowSchema) []*C.struct_ArrowSchema {
+	if schema.n_children <= 0 || schema.children == nil {
+		return nil
+	}
+	return unsafe.Slice(schema.children, int(schema.n_children))
+}
+
+// arrowSchemaToLogicalType converts an ArrowSchema exported by Kuzu to a
+// LogicalType. The type ID hint is used when it is known, because several
+// Kuzu types share the same Arrow format (e.g. STRING and UUID, or STRUCT and
+// NODE). Nested types without a hint are resolved from the Arrow format only.
+func arrowSchemaToLogicalType(schema *C.struct_ArrowSchema, hint TypeID) LogicalType {
+	format := C.GoString(schema.format)
+	logicalType := LogicalType{ID: hint}
+	if hint == TypeAny {
+		logicalType.ID = arrowFormatToTypeID(format)
+	}
+	children := arrowSchemaChildren(schema)
+	switch logicalType.ID {
+	case TypeList, TypeArray:
+		if len(children) == 1 {
+			logicalType.Children = []LogicalType{arrowSchemaToLogicalType(children[0], TypeAny)}
+		}
+		if strings.HasPrefix(format, "+w:") {
+			length, err := strconv.ParseUint(format[len("+w:"):], 10, 64)
+			if err == nil {
+				logicalType.ArrayLength = length
+			}
+		}
+	case TypeMap:
+		// An Arrow map has a single child, which is a struct of the key and
+		// the value.
+		if len(children) == 1 {
+			entries := arrowSchemaChildren(children[0])
+			if len(entries) == 2 {
+				logicalType.Children = []LogicalType{
+					arrowSchemaToLogicalType(entries[0], TypeAny),
+					arrowSchemaToLogicalType(entries[1], TypeAny),
+				}
+			}
+		}
+	case TypeStruct, TypeUnion, TypeNode, TypeRel, TypeRecursiveRel:
+		logicalType.Children = make([]LogicalType, 0, len(children))
+		logicalType.FieldNames = make([]string, 0, len(children))
+		for _, child := range children {
+			logicalType.Children = append(logicalType.Children, arrowSchemaToLogicalType(child, TypeAny))
+			logicalType.FieldNames = append(logicalType.FieldNames, C.GoString(child.name))
+		}
+	case TypeDecimal:
+		var precision, scale uint32
+		if _, err := fmt.Sscanf(format, "d:%d,%d", &precision, &scale); err == nil {
+			logicalType.Precision = precision
+			logicalType.Scale = scale
+		}
+	}
+	return logicalType
+}
+
+// arrowFormatToTypeID maps an Arrow format string to the Kuzu type ID that
+// Kuzu exports with that format.
+func arrowFormatToTypeID(format string) TypeID {
+	switch format {
+	case "b":
+		return TypeBool
+	case "c":
+		return TypeInt8
+	case "s":
+		return TypeInt16
+	case "i":
+		return TypeInt32
+	case "l":
+		return TypeInt64
+	case "C":
+		return TypeUint8
+	case "S":
+		return TypeUint16
+	case "I":
+		return TypeUint32
+	case "L":
+		return TypeUint64
+	case "f":
+		return TypeFloat
+	case "g":
+		return TypeDouble
+	case "u", "U":
+		return TypeString
+	case "z", "Z":
+		return TypeBlob
+	case "tdD":
+		return TypeDate
+	case "tss:":
+		return TypeTimestampSec
+	case "tsm:":
+		return TypeTimestampMs
+	case "tsu:":
+		return TypeTimestamp
+	case "tsn:":
+		return TypeTimestampNs
+	case "tDu", "tin":
+		return TypeInterval
+	case "+l", "+L":
+		return TypeList
+	case "+s":
+		return TypeStruct
+	case "+m":
+		return TypeMap
+	}
+	switch {
+	case format == "d:38,0":
+		return TypeInt128
+	case strings.HasPrefix(format, "d:"):
+		return TypeDecimal
+	case strings.HasPrefix(format, "tsu:"):
+		return TypeTimestampTz
+	case strings.HasPrefix(format, "+w:"):
+		return TypeArray
+	case strings.HasPrefix(format, "+ud:"), strings.HasPrefix(format, "+us:"):
+		return TypeUnion
+	}
+	return TypeAny
+}
+
+// logicalTypeToKuzu converts a LogicalType to a kuzu_logical_type, which the
+// caller must destroy. The C API cannot create STRUCT and MAP types directly,
+// so their type is taken from a value built from the default values of their
+// fields, keys and values. DECIMAL types cannot be created at all.
+func logicalTypeToKuzu(logicalType LogicalType) (C.kuzu_logical_type, error) {
+	var cLogicalType C.kuzu_logical_type
+	switch logicalType.ID {
+	case TypeList, TypeArray:
+		if len(logicalType.Children) != 1 {
+			return cLogicalType, newError(ErrorKindConversion, "a %s type must have exactly one child type", logicalType.ID)
+		}
+		childType, err := logicalTypeToKuzu(logicalType.Children[0])
+		if err != nil {
+			return cLogicalType, err
+		}
+		defer C.kuzu_data_type_destroy(&childType)
+		C.kuzu_data_type_create(C.kuzu_data_type_id(logicalType.ID), &childType, C.uint64_t(logicalType.ArrayLength), &cLogicalType)
+		return cLogicalType, nil
+	case TypeStruct, TypeMap:
+		defaultValue, err := defaultNestedKuzuValue(logicalType)
+		if err != nil {
+			return cLogicalType, err
+		}
+		defer C.kuzu_value_destroy(defaultValue)
+		C.kuzu_value_get_data_type(defaultValue, &cLogicalType)
+		return cLogicalType, nil
+	case TypeAny, TypeDecimal, TypeUnion, TypeNode, TypeRel, TypeRecursiveRel, TypeInternalID:
+		return cLogicalType, newError(ErrorKindConversion, "cannot create a parameter of type %s", logicalType)
+	}
+	C.kuzu_data_type_create(C.kuzu_data_type_id(logicalType.ID), nil, 0, &cLogicalType)
+	return cLogicalType, nil
+}
+
+// defaultNestedKuzuValue creates a kuzu_value of the STRUCT or MAP type made of
+// the default values of its field types, or of its key and value types.
+func defaultNestedKuzuValue(logicalType LogicalType) (*C.kuzu_value, error) {
+	children := make([]*C.kuzu_value, 0, len(logicalType.Children))
+	for _, child := range logicalType.Children {
+		childType, err := logicalTypeToKuzu(child)
+		if err != nil {
+			return nil, err
+		}
+		childValue := C.kuzu_value_create_default(&childType)
+		C.kuzu_data_type_destroy(&childType)
+		defer C.kuzu_value_destroy(childValue)
+		children = append(children, childValue)
+	}
+	var kuzuValue *C.kuzu_value
+	var status C.kuzu_state
+	if logicalType.ID == TypeMap {
+		if len(children) != 2 {
+			return nil, newError(ErrorKindConversion, "a MAP type must have exactly two child types")
+		}
+		status = C.kuzu_value_create_map(1, &children[0], &children[1], &kuzuValue)
+	} else {
+		if len(children) == 0 || len(children) != len(logicalType.FieldNames) {
+			return nil, newError(ErrorKindConversion, "a STRUCT type must have a name for each of its one or more fields")
+		}
+		fieldNames := make([]*C.char, len(logicalType.FieldNames))
+		for i, name := range logicalType.FieldNames {
+			fieldNames[i] = C.CString(name)
+			defer C.free(unsafe.Pointer(fieldNames[i]))
+		}
+		status = C.kuzu_value_create_struct(C.uint64_t(len(children)), &fieldNames[0], &children[0], &kuzuValue)
+	}
+	if status != C.KuzuSuccess {
+		return nil, newError(ErrorKindConversion, "failed to create %s type with status: %d", logicalType, status)
+	}
+	return kuzuValue, nil
+}
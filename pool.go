@@ -0,0 +1,369 @@
+package kuzu
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PoolConfig represents the configuration of a Pool.
+// MinConnections is the number of connections kept open even when they are
+// idle. The connections are opened when the pool is created.
+// MaxConnections is the maximum number of connections open at the same time.
+// Acquire waits for a connection to be released once it is reached.
+// IdleTimeout is the duration after which an idle connection is closed, as
+// long as more than MinConnections connections are open. A value of 0 means
+// that idle connections are never closed.
+// MaxNumThreads is the maximum number of threads used by each connection to
+// execute a query. A value of 0 means the default of the database.
+// Timeout is the query timeout of each connection in milliseconds. A value of
+// 0 means no timeout.
+type PoolConfig struct {
+	MinConnections int
+	MaxConnections int
+	IdleTimeout    time.Duration
+	MaxNumThreads  uint64
+	Timeout        uint64
+}
+
+// DefaultPoolConfig returns the default configuration of a Pool.
+// The default configuration keeps no idle connection open for more than a
+// minute and allows up to 16 connections.
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MinConnections: 0,
+		MaxConnections: 16,
+		IdleTimeout:    time.Minute,
+	}
+}
+
+// PoolStats represents the statistics of a Pool.
+// OpenConnections is the number of open connections, either in use or idle.
+// WaitCount is the number of times Acquire had to wait for a connection to be
+// released, and WaitDuration the total time spent waiting.
+// IdleClosed is the number of connections closed because of IdleTimeout.
+type PoolStats struct {
+	MaxConnections  int
+	OpenConnections int
+	InUse           int
+	Idle            int
+	AcquireCount    int64
+	WaitCount       int64
+	WaitDuration    time.Duration
+	IdleClosed      int64
+}
+
+// Pool represents a pool of connections to a Kuzu database.
+// A Pool is safe for concurrent use by multiple goroutines. Every connection
+// acquired from the pool must be released with Release once it is no longer
+// needed.
+type Pool struct {
+	database *Database
+	config   PoolConfig
+	// slots holds a token for every connection in use, bounding their number
+	// to MaxConnections.
+	slots      chan struct{}
+	mu         sync.Mutex
+	idle       []idleConnection
+	inUse      map[*Connection]struct{}
+	numThreads uint64
+	stats      PoolStats
+	isClosed   bool
+	stop       chan struct{}
+	stopped    chan struct{}
+}
+
+// idleConnection is a connection waiting in the pool since releasedAt.
+type idleConnection struct {
+	conn       *Connection
+	releasedAt time.Time
+}
+
+// NewPool creates a pool of connections to the specified database with the
+// specified configuration. MinConnections connections are opened right away.
+func NewPool(database *Database, config PoolConfig) (*Pool, error) {
+	if config.MaxConnections <= 0 {
+		return nil, newError(ErrorKindInvalidArgument, "the maximum number of connections must be positive, got %d", config.MaxConnections)
+	}
+	if config.MinConnections < 0 || config.MinConnections > config.MaxConnections {
+		return nil, newError(ErrorKindInvalidArgument, "the minimum number of connections must be between 0 and %d, got %d", config.MaxConnections, config.MinConnections)
+	}
+	pool := &Pool{
+		database:   database,
+		config:     config,
+		slots:      make(chan struct{}, config.MaxConnections),
+		inUse:      make(map[*Connection]struct{}),
+		numThreads: config.MaxNumThreads,
+		stop:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	if config.IdleTimeout > 0 {
+		go pool.closeIdleConnections()
+	} else {
+		close(pool.stopped)
+	}
+	for i := 0; i < config.MinConnections; i++ {
+		conn, err := pool.openConnection()
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+		pool.mu.Lock()
+		pool.idle = append(pool.idle, idleConnection{conn: conn, releasedAt: time.Now()})
+		pool.mu.Unlock()
+	}
+	return pool, nil
+}
+
+// Acquire returns a connection from the pool, opening a new one if no
+// connection is idle. If MaxConnections connections are already in use, it
+// waits until one of them is released or the context is done.
+// The connection must be released with Release once it is no longer needed.
+func (pool *Pool) Acquire(ctx context.Context) (*Connection, error) {
+	select {
+	case pool.slots <- struct{}{}:
+	default:
+		start := time.Now()
+		select {
+		case pool.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, contextError(ctx.Err(), nil)
+		}
+		pool.mu.Lock()
+		pool.stats.WaitCount++
+		pool.stats.WaitDuration += time.Since(start)
+		pool.mu.Unlock()
+	}
+	pool.mu.Lock()
+	if pool.isClosed {
+		pool.mu.Unlock()
+		<-pool.slots
+		return nil, closedError("pool")
+	}
+	pool.stats.AcquireCount++
+	for n := len(pool.idle); n > 0; n-- {
+		conn := pool.idle[n-1].conn
+		pool.idle = pool.idle[:n-1]
+		// An idle connection is closed along with the database.
+		if conn.closed() {
+			continue
+		}
+		pool.inUse[conn] = struct{}{}
+		pool.mu.Unlock()
+		return conn, nil
+	}
+	pool.mu.Unlock()
+	conn, err := pool.openConnection()
+	if err != nil {
+		<-pool.slots
+		return nil, err
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.isClosed {
+		conn.Close()
+		<-pool.slots
+		return nil, closedError("pool")
+	}
+	pool.inUse[conn] = struct{}{}
+	return conn, nil
+}
+
+// Release returns a connection acquired with Acquire to the pool. A
+// transaction left active on the connection is rolled back, the timeout and
+// the number of threads of the connection are restored to the defaults of the
+// pool, and its value options to the zero ValueOptions. The connection is closed instead if it cannot be reused or the
+// pool is closed. Releasing a connection that is not in use has no effect.
+func (pool *Pool) Release(conn *Connection) {
+	pool.mu.Lock()
+	if _, ok := pool.inUse[conn]; !ok {
+		pool.mu.Unlock()
+		return
+	}
+	delete(pool.inUse, conn)
+	pool.mu.Unlock()
+	defer func() { <-pool.slots }()
+	reusable := pool.resetConnection(conn)
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if !reusable || pool.isClosed {
+		conn.Close()
+		return
+	}
+	pool.idle = append(pool.idle, idleConnection{conn: conn, releasedAt: time.Now()})
+}
+
+// WithConnection acquires a connection from the pool, runs fn with it and
+// releases it, even if fn panics.
+func (pool *Pool) WithConnection(ctx context.Context, fn func(conn *Connection) error) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer pool.Release(conn)
+	return fn(conn)
+}
+
+// Query executes the specified query string on a connection of the pool and
+// returns the result. The connection stays in use until the result is closed,
+// so that it is neither closed nor reused while the result is read. It is
+// released right away if the query fails.
+func (pool *Pool) Query(ctx context.Context, query string) (*QueryResult, error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queryResult, err := conn.QueryContext(ctx, query)
+	return pool.releaseOnClose(conn, queryResult, err)
+}
+
+// Execute prepares the specified query string on a connection of the pool,
+// executes it with the specified arguments and returns the result. As with
+// Query, the connection stays in use until the result is closed.
+func (pool *Pool) Execute(ctx context.Context, query string, args map[string]any) (*QueryResult, error) {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	preparedStatement, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		pool.Release(conn)
+		return nil, err
+	}
+	defer preparedStatement.Close()
+	queryResult, err := conn.ExecuteContext(ctx, preparedStatement, args)
+	return pool.releaseOnClose(conn, queryResult, err)
+}
+
+// releaseOnClose arranges for the connection to be released once the query
+// result executed on it is closed. If the query failed, the query result is
+// closed and the connection released right away.
+func (pool *Pool) releaseOnClose(conn *Connection, queryResult *QueryResult, err error) (*QueryResult, error) {
+	if err != nil {
+		if queryResult != nil {
+			queryResult.Close()
+		}
+		pool.Release(conn)
+		return nil, err
+	}
+	queryResult.setOnClose(func() {
+		pool.Release(conn)
+	})
+	return queryResult, nil
+}
+
+// Stats returns the statistics of the pool.
+func (pool *Pool) Stats() PoolStats {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	stats := pool.stats
+	stats.MaxConnections = pool.config.MaxConnections
+	stats.InUse = len(pool.inUse)
+	stats.Idle = len(pool.idle)
+	stats.OpenConnections = stats.InUse + stats.Idle
+	return stats
+}
+
+// Close closes the pool and its idle connections. The connections in use are
+// closed when they are released. Acquire fails with an error matching
+// ErrClosed once the pool is closed. It is safe to call Close multiple times.
+func (pool *Pool) Close() {
+	pool.mu.Lock()
+	if pool.isClosed {
+		pool.mu.Unlock()
+		return
+	}
+	pool.isClosed = true
+	idle := pool.idle
+	pool.idle = nil
+	pool.mu.Unlock()
+	close(pool.stop)
+	<-pool.stopped
+	for _, idleConn := range idle {
+		idleConn.conn.Close()
+	}
+}
+
+// openConnection opens a new connection configured with the defaults of the
+// pool.
+func (pool *Pool) openConnection() (*Connection, error) {
+	conn, err := OpenConnection(pool.database)
+	if err != nil {
+		return nil, err
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.numThreads == 0 {
+		pool.numThreads = conn.GetMaxNumThreads()
+	} else {
+		conn.SetMaxNumThreads(pool.numThreads)
+	}
+	if pool.config.Timeout > 0 {
+		conn.SetTimeout(pool.config.Timeout)
+	}
+	return conn, nil
+}
+
+// resetConnection restores the defaults of the pool on a released
+// connection. It returns false if the connection cannot be reused.
+func (pool *Pool) resetConnection(conn *Connection) bool {
+	conn.mu.Lock()
+	isClosed, transaction := conn.isClosed, conn.transaction
+	conn.mu.Unlock()
+	if isClosed {
+		return false
+	}
+	if transaction != nil {
+		if err := transaction.Rollback(); err != nil {
+			return false
+		}
+	}
+	pool.mu.Lock()
+	numThreads := pool.numThreads
+	pool.mu.Unlock()
+	conn.SetMaxNumThreads(numThreads)
+	conn.SetTimeout(pool.config.Timeout)
+	conn.SetValueOptions(ValueOptions{})
+	return true
+}
+
+// closeIdleConnections periodically closes the connections idle for longer
+// than IdleTimeout, keeping at least MinConnections connections open, until
+// the pool is closed.
+func (pool *Pool) closeIdleConnections() {
+	defer close(pool.stopped)
+	interval := pool.config.IdleTimeout / 2
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pool.stop:
+			return
+		case now := <-ticker.C:
+			for _, conn := range pool.expiredConnections(now) {
+				conn.Close()
+			}
+		}
+	}
+}
+
+// expiredConnections removes from the pool and returns the idle connections
+// that have expired at the specified time.
+func (pool *Pool) expiredConnections(now time.Time) []*Connection {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	var expired []*Connection
+	// The idle connections are ordered by release time, the oldest first.
+	for len(pool.idle) > 0 && len(pool.idle)+len(pool.inUse) > pool.config.MinConnections {
+		if now.Sub(pool.idle[0].releasedAt) < pool.config.IdleTimeout {
+			break
+		}
+		expired = append(expired, pool.idle[0].conn)
+		pool.idle = pool.idle[1:]
+		pool.stats.IdleClosed++
+	}
+	return expired
+}
@@ -0,0 +1,232 @@
+package kuzu
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolAcquireRelease(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	config := DefaultPoolConfig()
+	config.MinConnections = 1
+	config.MaxConnections = 2
+	config.MaxNumThreads = 1
+	config.Timeout = 60000
+	pool, err := NewPool(db, config)
+	assert.Nil(t, err)
+	defer pool.Close()
+	assert.Equal(t, PoolStats{MaxConnections: 2, OpenConnections: 1, Idle: 1}, pool.Stats())
+	conn1, err := pool.Acquire(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), conn1.GetMaxNumThreads())
+	conn2, err := pool.Acquire(context.Background())
+	assert.Nil(t, err)
+	assert.NotSame(t, conn1, conn2)
+	stats := pool.Stats()
+	assert.Equal(t, 2, stats.InUse)
+	assert.Equal(t, 0, stats.Idle)
+	assert.Equal(t, int64(2), stats.AcquireCount)
+	// The pool is exhausted, so Acquire waits until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = pool.Acquire(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, errors.Is(err, ErrTimeout))
+	// The settings changed on a connection are reset when it is released.
+	conn1.SetMaxNumThreads(2)
+	pool.Release(conn1)
+	pool.Release(conn1)
+	conn3, err := pool.Acquire(context.Background())
+	assert.Nil(t, err)
+	assert.Same(t, conn1, conn3)
+	assert.Equal(t, uint64(1), conn3.GetMaxNumThreads())
+	pool.Release(conn2)
+	pool.Release(conn3)
+	assert.Equal(t, PoolStats{MaxConnections: 2, OpenConnections: 2, Idle: 2, AcquireCount: 3}, pool.Stats())
+}
+
+func TestPoolWait(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	config := DefaultPoolConfig()
+	config.MaxConnections = 1
+	pool, err := NewPool(db, config)
+	assert.Nil(t, err)
+	defer pool.Close()
+	conn, err := pool.Acquire(context.Background())
+	assert.Nil(t, err)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		pool.Release(conn)
+	}()
+	waited, err := pool.Acquire(context.Background())
+	assert.Nil(t, err)
+	assert.Same(t, conn, waited)
+	pool.Release(waited)
+	stats := pool.Stats()
+	assert.Equal(t, int64(1), stats.WaitCount)
+	assert.True(t, stats.WaitDuration > 0)
+}
+
+func TestPoolReleaseRollsBackTransaction(t *testing.T) {
+	db, err := OpenInMemoryDatabase(DefaultSystemConfig())
+	assert.Nil(t, err)
+	defer db.Close()
+	config := DefaultPoolConfig()
+	config.MaxConnections = 1
+	pool, err := NewPool(db, config)
+	assert.Nil(t, err)
+	defer pool.Close()
+	res, err := pool.Query(context.Background(), "CREATE NODE TABLE person(id INT64, PRIMARY KEY(id));")
+	assert.Nil(t, err)
+	res.Close()
+	conn, err := pool.Acquire(context.Background())
+	assert.Nil(t, err)
+	tx, err := conn.Begin(TransactionOptions{})
+	assert.Nil(t, err)
+	res, err = tx.Query("CREATE (:person {id: 1});")
+	assert.Nil(t, err)
+	res.Close()
+	pool.Release(conn)
+	res, err = pool.Execute(context.Background(), "MATCH (p:person) WHERE p.id = $id RETURN COUNT(*);", map[string]any{"id": int64(1)})
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	count, err := tuple.GetValue(0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+	tuple.Close()
+	res.Close()
+}
+
+func TestPoolReleaseResetsValueOptions(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	config := DefaultPoolConfig()
+	config.MaxConnections = 1
+	pool, err := NewPool(db, config)
+	assert.Nil(t, err)
+	defer pool.Close()
+	conn, err := pool.Acquire(context.Background())
+	assert.Nil(t, err)
+	conn.SetValueOptions(ValueOptions{GoMaps: true, Location: time.UTC, IntervalAsDuration: true})
+	pool.Release(conn)
+	reacquired, err := pool.Acquire(context.Background())
+	assert.Nil(t, err)
+	defer pool.Release(reacquired)
+	assert.Same(t, conn, reacquired)
+	assert.Equal(t, ValueOptions{}, reacquired.GetValueOptions())
+}
+
+func TestPoolIdleTimeout(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	config := DefaultPoolConfig()
+	config.MinConnections = 1
+	config.MaxConnections = 3
+	config.IdleTimeout = 20 * time.Millisecond
+	pool, err := NewPool(db, config)
+	assert.Nil(t, err)
+	defer pool.Close()
+	var conns []*Connection
+	for i := 0; i < 3; i++ {
+		conn, err := pool.Acquire(context.Background())
+		assert.Nil(t, err)
+		conns = append(conns, conn)
+	}
+	for _, conn := range conns {
+		pool.Release(conn)
+	}
+	assert.Eventually(t, func() bool {
+		return pool.Stats().OpenConnections == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(2), pool.Stats().IdleClosed)
+}
+
+func TestPoolQueryKeepsConnection(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	config := DefaultPoolConfig()
+	config.IdleTimeout = 20 * time.Millisecond
+	pool, err := NewPool(db, config)
+	assert.Nil(t, err)
+	defer pool.Close()
+	res, err := pool.Query(context.Background(), "UNWIND [1, 2, 3] AS i RETURN i;")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, pool.Stats().InUse)
+	// The connection is not closed as idle while the result is in use.
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int64(0), pool.Stats().IdleClosed)
+	var values []any
+	for res.HasNext() {
+		tuple, err := res.Next()
+		assert.Nil(t, err)
+		value, err := tuple.GetValue(0)
+		assert.Nil(t, err)
+		values = append(values, value)
+	}
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, values)
+	res.Close()
+	assert.Equal(t, 0, pool.Stats().InUse)
+	_, err = pool.Query(context.Background(), "RETURN a;")
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, pool.Stats().InUse)
+}
+
+func TestPoolConcurrentQueries(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	config := DefaultPoolConfig()
+	config.MaxConnections = 4
+	pool, err := NewPool(db, config)
+	assert.Nil(t, err)
+	defer pool.Close()
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := pool.Query(context.Background(), "MATCH (a:person) RETURN COUNT(*);")
+			if !assert.Nil(t, err) {
+				return
+			}
+			tuple, err := res.Next()
+			assert.Nil(t, err)
+			count, err := tuple.GetValue(0)
+			assert.Nil(t, err)
+			assert.Equal(t, int64(8), count)
+			tuple.Close()
+			res.Close()
+		}()
+	}
+	wg.Wait()
+	stats := pool.Stats()
+	assert.Equal(t, 0, stats.InUse)
+	assert.LessOrEqual(t, stats.OpenConnections, 4)
+}
+
+func TestPoolClose(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	pool, err := NewPool(db, DefaultPoolConfig())
+	assert.Nil(t, err)
+	conn, err := pool.Acquire(context.Background())
+	assert.Nil(t, err)
+	pool.Close()
+	pool.Close()
+	_, err = pool.Acquire(context.Background())
+	assert.True(t, errors.Is(err, ErrClosed))
+	// The connections in use are closed when they are released.
+	pool.Release(conn)
+	_, err = conn.Query("RETURN 1;")
+	assert.True(t, errors.Is(err, ErrClosed))
+}
+
+func TestNewPoolInvalidConfig(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	_, err := NewPool(db, PoolConfig{})
+	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = NewPool(db, PoolConfig{MinConnections: 2, MaxConnections: 1})
+	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
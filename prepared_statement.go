@@ -4,21 +4,36 @@ package kuzu
 // #include <stdlib.h>
 import "C"
 
+import "sync"
+
 // PreparedStatement represents a prepared statement in Kuzu, which can be
 // used to execute a query with parameters.
 // PreparedStatement is returned by the `Prepare` method of Connection.
 type PreparedStatement struct {
 	cPreparedStatement C.kuzu_prepared_statement
 	connection         *Connection
+	query              string
 	isClosed           bool
+	mu                 sync.Mutex
 }
 
 // Close closes the PreparedStatement. Calling this method is optional.
 // The PreparedStatement will be closed automatically when it is garbage collected.
+// It is safe to call Close multiple times and concurrently.
 func (stmt *PreparedStatement) Close() {
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
 	if stmt.isClosed {
 		return
 	}
-	C.kuzu_prepared_statement_destroy(&stmt.cPreparedStatement)
 	stmt.isClosed = true
+	conn := stmt.connection
+	conn.statements.remove(stmt)
+	// The connection is only closed before the prepared statement if the
+	// prepared statement was already unreachable.
+	conn.handleMu.RLock()
+	defer conn.handleMu.RUnlock()
+	if !conn.isClosed {
+		C.kuzu_prepared_statement_destroy(&stmt.cPreparedStatement)
+	}
 }
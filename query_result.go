@@ -5,25 +5,49 @@ package kuzu
 import "C"
 
 import (
-	"fmt"
 	"runtime"
+	"sync"
 	"unsafe"
 )
 
 // QueryResult represents the result of a query, which can be used to iterate
 // over the result set.
 // QueryResult is returned by the `Query` and `Execute` methods of Connection.
+// The methods of QueryResult are safe for concurrent use. Once the QueryResult
+// is closed, they return zero values, and the methods returning an error fail
+// with an error matching ErrClosed.
 type QueryResult struct {
 	cQueryResult C.kuzu_query_result
 	connection   *Connection
-	isClosed     bool
-	columnNames  []string
+	// parent is the query result that the query result was obtained from with
+	// NextQueryResult, if any. It owns the C query result.
+	parent      *QueryResult
+	nextResults children[QueryResult]
+	isClosed    bool
+	columnNames []string
+	columnTypes []LogicalType
+	// numRows is the number of rows of the result set, which is only set
+	// once hasNumRows is true.
+	numRows    uint64
+	hasNumRows bool
+	// valueOptions are the options of the connection when the query was
+	// executed.
+	valueOptions ValueOptions
+	// onClose is run once the QueryResult is closed, such as to release the
+	// connection of a Pool that the query was executed on.
+	onClose func()
+	mu      sync.Mutex
 }
 
 // ToString returns the string representation of the QueryResult.
 // The string representation contains the column names and the tuples in the
 // result set.
 func (queryResult *QueryResult) ToString() string {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.isClosed {
+		return ""
+	}
 	cString := C.kuzu_query_result_to_string(&queryResult.cQueryResult)
 	str := C.GoString(cString)
 	C.free(unsafe.Pointer(cString))
@@ -32,25 +56,78 @@ func (queryResult *QueryResult) ToString() string {
 
 // Close closes the QueryResult. Calling this method is optional.
 // The QueryResult will be closed automatically when it is garbage collected.
+// The query results obtained from it with NextQueryResult are closed as well,
+// and the FlatTuples obtained from it can no longer be used.
+// It is safe to call Close multiple times and concurrently.
 func (queryResult *QueryResult) Close() {
+	if onClose := queryResult.close(); onClose != nil {
+		onClose()
+	}
+}
+
+// close closes the QueryResult and returns its onClose function, if any. The
+// caller runs the function once it no longer holds the statement lock of the
+// connection, which the function may need.
+func (queryResult *QueryResult) close() func() {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
 	if queryResult.isClosed {
-		return
+		return nil
 	}
-	C.kuzu_query_result_destroy(&queryResult.cQueryResult)
 	queryResult.isClosed = true
+	for _, nextQueryResult := range queryResult.nextResults.drain() {
+		nextQueryResult.Close()
+	}
+	if queryResult.parent != nil {
+		queryResult.parent.nextResults.remove(queryResult)
+		return queryResult.onClose
+	}
+	conn := queryResult.connection
+	conn.results.remove(queryResult)
+	// The connection is only closed before the query result if the query
+	// result was already unreachable.
+	conn.handleMu.RLock()
+	defer conn.handleMu.RUnlock()
+	if !conn.isClosed {
+		C.kuzu_query_result_destroy(&queryResult.cQueryResult)
+	}
+	return queryResult.onClose
+}
+
+// setOnClose sets the function run once the QueryResult is closed. The
+// function is run right away if the QueryResult is already closed.
+func (queryResult *QueryResult) setOnClose(onClose func()) {
+	queryResult.mu.Lock()
+	if queryResult.isClosed {
+		queryResult.mu.Unlock()
+		onClose()
+		return
+	}
+	queryResult.onClose = onClose
+	queryResult.mu.Unlock()
 }
 
 // ResetIterator resets the iterator of the QueryResult. After calling this method, the `Next`
 // method can be called to iterate over the result set from the beginning.
 func (queryResult *QueryResult) ResetIterator() {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.isClosed {
+		return
+	}
 	C.kuzu_query_result_reset_iterator(&queryResult.cQueryResult)
 }
 
 // GetColumnNames returns the column names of the QueryResult as a slice of strings.
 func (queryResult *QueryResult) GetColumnNames() []string {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
 	if queryResult.columnNames != nil {
 		return queryResult.columnNames
 	}
+	if queryResult.isClosed {
+		return nil
+	}
 	numColumns := int64(C.kuzu_query_result_get_num_columns(&queryResult.cQueryResult))
 	columns := make([]string, 0, numColumns)
 	for i := int64(0); i < numColumns; i++ {
@@ -65,24 +142,46 @@ func (queryResult *QueryResult) GetColumnNames() []string {
 
 // GetNumberOfColumns returns the number of columns in the QueryResult.
 func (queryResult *QueryResult) GetNumberOfColumns() uint64 {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.isClosed {
+		return 0
+	}
 	return uint64(C.kuzu_query_result_get_num_columns(&queryResult.cQueryResult))
 }
 
 // GetNumberOfRows returns the number of rows in the QueryResult.
 func (queryResult *QueryResult) GetNumberOfRows() uint64 {
-	if queryResult.columnNames != nil {
-		return uint64(len(queryResult.columnNames))
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.hasNumRows {
+		return queryResult.numRows
 	}
-	return uint64(C.kuzu_query_result_get_num_tuples(&queryResult.cQueryResult))
+	if queryResult.isClosed {
+		return 0
+	}
+	queryResult.numRows = uint64(C.kuzu_query_result_get_num_tuples(&queryResult.cQueryResult))
+	queryResult.hasNumRows = true
+	return queryResult.numRows
 }
 
 // HasNext returns true if there is at least one more tuple in the result set.
 func (queryResult *QueryResult) HasNext() bool {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.isClosed {
+		return false
+	}
 	return bool(C.kuzu_query_result_has_next(&queryResult.cQueryResult))
 }
 
 // Next returns the next tuple in the result set.
 func (queryResult *QueryResult) Next() (*FlatTuple, error) {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.isClosed {
+		return nil, closedError("query result")
+	}
 	tuple := &FlatTuple{}
 	runtime.SetFinalizer(tuple, func(tuple *FlatTuple) {
 		tuple.Close()
@@ -90,7 +189,7 @@ func (queryResult *QueryResult) Next() (*FlatTuple, error) {
 	tuple.queryResult = queryResult
 	status := C.kuzu_query_result_get_next(&queryResult.cQueryResult, &tuple.cFlatTuple)
 	if status != C.KuzuSuccess {
-		return tuple, fmt.Errorf("failed to get next tuple with status %d", status)
+		return tuple, newError(ErrorKindRuntime, "failed to get next tuple with status %d", status)
 	}
 	return tuple, nil
 }
@@ -98,24 +197,43 @@ func (queryResult *QueryResult) Next() (*FlatTuple, error) {
 // HasNextQueryResult returns true not all the query results is consumed when
 // multiple query statements are executed.
 func (queryResult *QueryResult) HasNextQueryResult() bool {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.isClosed {
+		return false
+	}
 	return bool(C.kuzu_query_result_has_next_query_result(&queryResult.cQueryResult))
 }
 
 // NextQueryResult returns the next query result when multiple query statements are executed.
 func (queryResult *QueryResult) NextQueryResult() (*QueryResult, error) {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.isClosed {
+		return nil, closedError("query result")
+	}
 	nextQueryResult := &QueryResult{}
+	nextQueryResult.connection = queryResult.connection
+	nextQueryResult.valueOptions = queryResult.valueOptions
+	nextQueryResult.parent = queryResult
 	runtime.SetFinalizer(nextQueryResult, func(nextQueryResult *QueryResult) {
 		nextQueryResult.Close()
 	})
+	queryResult.nextResults.add(nextQueryResult)
 	status := C.kuzu_query_result_get_next_query_result(&queryResult.cQueryResult, &nextQueryResult.cQueryResult)
 	if status != C.KuzuSuccess {
-		return nextQueryResult, fmt.Errorf("failed to get next query result with status %d", status)
+		return nextQueryResult, newError(ErrorKindRuntime, "failed to get next query result with status %d", status)
 	}
 	return nextQueryResult, nil
 }
 
 // GetCompilingTime returns the compiling time of the query in milliseconds.
 func (queryResult *QueryResult) GetCompilingTime() float64 {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.isClosed {
+		return 0
+	}
 	var cQuerySummary C.kuzu_query_summary
 	C.kuzu_query_result_get_query_summary(&queryResult.cQueryResult, &cQuerySummary)
 	defer C.kuzu_query_summary_destroy(&cQuerySummary)
@@ -124,6 +242,11 @@ func (queryResult *QueryResult) GetCompilingTime() float64 {
 
 // GetExecutionTime returns the execution time of the query in milliseconds.
 func (queryResult *QueryResult) GetExecutionTime() float64 {
+	queryResult.mu.Lock()
+	defer queryResult.mu.Unlock()
+	if queryResult.isClosed {
+		return 0
+	}
 	var cQuerySummary C.kuzu_query_summary
 	C.kuzu_query_result_get_query_summary(&queryResult.cQueryResult, &cQuerySummary)
 	defer C.kuzu_query_summary_destroy(&cQuerySummary)
@@ -1,6 +1,8 @@
 package kuzu
 
 import (
+	"errors"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -74,6 +76,10 @@ func TestQueryResultGetNumberOfRows(t *testing.T) {
 	assert.Nil(t, err)
 	numRows := res.GetNumberOfRows()
 	assert.Equal(t, uint64(8), numRows)
+	// The column names are cached by GetColumnNames, which must not change
+	// the number of rows returned afterwards.
+	assert.Len(t, res.GetColumnNames(), 1)
+	assert.Equal(t, uint64(8), res.GetNumberOfRows())
 	res.Close()
 }
 
@@ -157,3 +163,106 @@ func TestQueryResultGetExecutionTime(t *testing.T) {
 	assert.Greater(t, res.GetExecutionTime(), float64(0))
 	res.Close()
 }
+
+func TestQueryResultGetColumnTypes(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) WHERE a.ID = 0 RETURN a.ID, a.fName, a.workedHours, a.courseScoresPerTerm, a.grades, a.u;")
+	assert.Nil(t, err)
+	columnTypes := res.GetColumnTypes()
+	assert.Equal(t, 6, len(columnTypes))
+	assert.Equal(t, TypeInt64, columnTypes[0].ID)
+	assert.Equal(t, "INT64", columnTypes[0].String())
+	assert.Equal(t, TypeString, columnTypes[1].ID)
+	assert.Equal(t, TypeList, columnTypes[2].ID)
+	assert.Equal(t, "INT64[]", columnTypes[2].String())
+	assert.Equal(t, "INT64[][]", columnTypes[3].String())
+	assert.Equal(t, TypeArray, columnTypes[4].ID)
+	assert.Equal(t, uint64(4), columnTypes[4].ArrayLength)
+	assert.Equal(t, "INT64[4]", columnTypes[4].String())
+	assert.Equal(t, TypeUUID, columnTypes[5].ID)
+	res.Close()
+}
+
+func TestQueryResultGetColumnTypesNested(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (o:organisation) WHERE o.ID = 1 RETURN o.state, o.info, o;")
+	assert.Nil(t, err)
+	columnTypes := res.GetColumnTypes()
+	assert.Equal(t, 3, len(columnTypes))
+	assert.Equal(t, TypeStruct, columnTypes[0].ID)
+	assert.Equal(t, []string{"revenue", "location", "stock"}, columnTypes[0].FieldNames)
+	assert.Equal(t, "STRUCT(revenue INT16, location STRING[], stock STRUCT(price INT64[], volume INT64))", columnTypes[0].String())
+	assert.Equal(t, TypeUnion, columnTypes[1].ID)
+	assert.Equal(t, "UNION(price FLOAT, movein DATE, note STRING)", columnTypes[1].String())
+	assert.Equal(t, TypeNode, columnTypes[2].ID)
+	res.Close()
+
+	res, err = conn.Query("MATCH (m:movies) WHERE m.name = 'Roma' RETURN m.audience, CAST(1.5, 'DECIMAL(10, 2)');")
+	assert.Nil(t, err)
+	columnTypes = res.GetColumnTypes()
+	assert.Equal(t, "MAP(STRING, INT64)", columnTypes[0].String())
+	assert.Equal(t, TypeDecimal, columnTypes[1].ID)
+	assert.Equal(t, uint32(10), columnTypes[1].Precision)
+	assert.Equal(t, uint32(2), columnTypes[1].Scale)
+	assert.Equal(t, "DECIMAL(10, 2)", columnTypes[1].String())
+	res.Close()
+}
+
+func TestQueryResultConcurrentClose(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) RETURN a.fName;")
+	assert.Nil(t, err)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for res.HasNext() {
+				tuple, err := res.Next()
+				if err != nil {
+					assert.True(t, errors.Is(err, ErrClosed))
+					return
+				}
+				_, err = tuple.GetValue(0)
+				assert.Nil(t, err)
+				tuple.Close()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			res.Close()
+		}()
+	}
+	wg.Wait()
+	assert.False(t, res.HasNext())
+	_, err = res.Next()
+	assert.True(t, errors.Is(err, ErrClosed))
+	assert.Nil(t, res.GetColumnNames())
+	assert.Equal(t, "", res.ToString())
+}
+
+func TestTupleClosed(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("RETURN 1;")
+	assert.Nil(t, err)
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	tuple.Close()
+	_, err = tuple.GetValue(0)
+	assert.True(t, errors.Is(err, ErrClosed))
+	assert.Equal(t, "", tuple.GetAsString())
+	res.Close()
+}
+
+func TestCloseQueryResultClosesNextQueryResults(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("RETURN 1; RETURN 2;")
+	assert.Nil(t, err)
+	next, err := res.NextQueryResult()
+	assert.Nil(t, err)
+	res.Close()
+	assert.True(t, next.isClosed)
+	_, err = next.Next()
+	assert.True(t, errors.Is(err, ErrClosed))
+	next.Close()
+}
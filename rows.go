@@ -0,0 +1,171 @@
+package kuzu
+
+import (
+	"context"
+	"iter"
+	"reflect"
+)
+
+// Rows returns an iterator over the remaining tuples of the QueryResult,
+// each converted to a value of type T.
+// If T is a struct, or a pointer to one, the columns are mapped to its fields
+// as in FlatTuple.ScanStruct, unless the query result has a single NODE or REL
+// column, whose properties are then decoded into T as in Node.Decode.
+// Otherwise, and for the struct types that Kuzu values convert to directly
+// such as time.Time or Node, the query result must have exactly one column,
+// which is converted as in FlatTuple.Scan.
+// Every tuple is closed before the next one is read. The iteration stops
+// after the first error, which is yielded together with the zero value of T.
+func Rows[T any](queryResult *QueryResult) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for queryResult.HasNext() {
+			value, err := nextRow[T](queryResult)
+			if !yield(value, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// CollectAll reads the remaining tuples of the QueryResult into a slice of
+// values of type T, converted as in Rows. It returns the values read so far
+// and the first error encountered.
+func CollectAll[T any](queryResult *QueryResult) ([]T, error) {
+	var values []T
+	for value, err := range Rows[T](queryResult) {
+		if err != nil {
+			return values, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// StreamRow is a row sent by QueryResult.Stream. Values holds the values of
+// the tuple as returned by FlatTuple.GetAsSlice, and Err the error that
+// occurred while reading it, in which case Values may be incomplete or nil.
+type StreamRow struct {
+	Values []any
+	Err    error
+}
+
+// Stream returns a channel of the remaining tuples of the QueryResult, read by
+// a dedicated goroutine so that they can be processed while the next ones are
+// read. bufferSize is the capacity of the channel, i.e. how many rows may be
+// read ahead of the consumer.
+// Every tuple is closed as soon as its values are read. The channel is closed
+// once all the tuples are read or after the first error, which is sent as a
+// StreamRow with a non-nil Err.
+// If the context is cancelled or its deadline passes, the goroutine stops
+// reading and closes the channel after sending an error wrapping ctx.Err() if
+// there is room for it in the buffer, so that it never blocks on a consumer
+// that has stopped receiving. The query has already completed and the
+// connection may be running another statement, so the connection is not
+// interrupted, and the remaining tuples can still be read. Closing the
+// QueryResult also ends the stream, without an error. The QueryResult must
+// not be iterated otherwise until the channel is closed, and it is not closed
+// by Stream.
+func (queryResult *QueryResult) Stream(ctx context.Context, bufferSize int) <-chan StreamRow {
+	rows := make(chan StreamRow, max(bufferSize, 0))
+	go queryResult.stream(ctx, rows)
+	return rows
+}
+
+// stream sends the remaining tuples of the QueryResult to rows as described in
+// Stream, and closes it.
+func (queryResult *QueryResult) stream(ctx context.Context, rows chan<- StreamRow) {
+	defer close(rows)
+	for ctx.Err() == nil && queryResult.HasNext() {
+		values, err := nextValues(queryResult)
+		select {
+		case rows <- StreamRow{Values: values, Err: err}:
+			if err != nil {
+				return
+			}
+		case <-ctx.Done():
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		select {
+		case rows <- StreamRow{Err: contextError(err, nil)}:
+		default:
+		}
+	}
+}
+
+// nextValues reads the values of the next tuple of the QueryResult, closing
+// the tuple.
+func nextValues(queryResult *QueryResult) ([]any, error) {
+	tuple, err := queryResult.Next()
+	if tuple != nil {
+		defer tuple.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tuple.GetAsSlice()
+}
+
+// nextRow reads the next tuple of the QueryResult and converts it to T.
+func nextRow[T any](queryResult *QueryResult) (T, error) {
+	var value T
+	tuple, err := queryResult.Next()
+	if err != nil {
+		if tuple != nil {
+			tuple.Close()
+		}
+		return value, err
+	}
+	defer tuple.Close()
+	if isRowStruct(reflect.TypeOf(value)) && !isSingleEntityColumn(queryResult) {
+		err = scanRowStruct(tuple, &value)
+	} else {
+		err = tuple.Scan(&value)
+	}
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value, nil
+}
+
+// scanRowStruct scans the tuple into the struct pointed to by dest, which may
+// be a pointer to a struct or a pointer to a pointer to a struct.
+func scanRowStruct(tuple *FlatTuple, dest any) error {
+	destValue := reflect.ValueOf(dest).Elem()
+	if destValue.Kind() == reflect.Ptr {
+		destValue.Set(reflect.New(destValue.Type().Elem()))
+		return tuple.ScanStruct(destValue.Interface())
+	}
+	return tuple.ScanStruct(dest)
+}
+
+// isRowStruct returns true if the tuples are scanned into values of the type
+// by mapping the columns to struct fields.
+func isRowStruct(rowType reflect.Type) bool {
+	if rowType == nil {
+		return false
+	}
+	if rowType.Kind() == reflect.Ptr {
+		rowType = rowType.Elem()
+	}
+	if rowType.Kind() != reflect.Struct {
+		return false
+	}
+	for _, scanType := range scanTypes {
+		if scanType == rowType {
+			return false
+		}
+	}
+	return true
+}
+
+// isSingleEntityColumn returns true if the query result has a single column
+// of type NODE or REL.
+func isSingleEntityColumn(queryResult *QueryResult) bool {
+	columnTypes := queryResult.GetColumnTypes()
+	if len(columnTypes) != 1 {
+		return false
+	}
+	return columnTypes[0].ID == TypeNode || columnTypes[0].ID == TypeRel
+}
@@ -0,0 +1,183 @@
+package kuzu
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type rowsTestPerson struct {
+	ID        int64     `kuzu:"a.ID"`
+	Name      string    `kuzu:"a.fName"`
+	Age       int       `kuzu:"a.age"`
+	Birthdate time.Time `kuzu:"a.birthdate"`
+}
+
+func TestRows(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) RETURN a.ID, a.fName, a.age, a.birthdate ORDER BY a.ID LIMIT 3;")
+	assert.Nil(t, err)
+	var persons []rowsTestPerson
+	for person, err := range Rows[rowsTestPerson](res) {
+		assert.Nil(t, err)
+		persons = append(persons, person)
+	}
+	assert.Equal(t, 3, len(persons))
+	assert.Equal(t, int64(0), persons[0].ID)
+	assert.Equal(t, "Alice", persons[0].Name)
+	assert.Equal(t, 35, persons[0].Age)
+	assert.Equal(t, 1900, persons[0].Birthdate.Year())
+	assert.Equal(t, "Bob", persons[1].Name)
+	res.Close()
+}
+
+func TestRowsPointer(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) RETURN a.ID, a.fName ORDER BY a.ID LIMIT 2;")
+	assert.Nil(t, err)
+	persons, err := CollectAll[*rowsTestPerson](res)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(persons))
+	assert.Equal(t, "Alice", persons[0].Name)
+	assert.Equal(t, "Bob", persons[1].Name)
+	res.Close()
+}
+
+func TestRowsNode(t *testing.T) {
+	type person struct {
+		Label string `kuzu:"_label"`
+		ID    int64
+		Name  string `kuzu:"fName"`
+	}
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) RETURN a ORDER BY a.ID LIMIT 2;")
+	assert.Nil(t, err)
+	persons, err := CollectAll[*person](res)
+	assert.Nil(t, err)
+	assert.Equal(t, []*person{{"person", 0, "Alice"}, {"person", 2, "Bob"}}, persons)
+	res.Close()
+}
+
+func TestRowsBreak(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) RETURN a.ID ORDER BY a.ID;")
+	assert.Nil(t, err)
+	for id, err := range Rows[int64](res) {
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), id)
+		break
+	}
+	// The iteration can be resumed after breaking out of the loop.
+	ids, err := CollectAll[int](res)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3, 5, 7, 8, 9, 10}, ids)
+	res.Close()
+}
+
+func TestCollectAllScalar(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) WHERE a.ID < 3 RETURN a.birthdate ORDER BY a.ID;")
+	assert.Nil(t, err)
+	birthdates, err := CollectAll[time.Time](res)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(birthdates))
+	assert.Equal(t, 1900, birthdates[0].Year())
+	res.Close()
+
+	res, err = conn.Query("MATCH (a:person) WHERE a.ID = 0 RETURN a;")
+	assert.Nil(t, err)
+	nodes, err := CollectAll[Node](res)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(nodes))
+	assert.Equal(t, "person", nodes[0].Label)
+	res.Close()
+}
+
+func TestCollectAllError(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) RETURN a.fName ORDER BY a.ID;")
+	assert.Nil(t, err)
+	values, err := CollectAll[int64](res)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, len(values))
+	res.Close()
+}
+
+func TestStream(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("UNWIND range(1, 100) AS i RETURN i, i * 2 AS j;")
+	assert.Nil(t, err)
+	defer res.Close()
+	count := int64(0)
+	for row := range res.Stream(context.Background(), 4) {
+		assert.Nil(t, row.Err)
+		count++
+		assert.Equal(t, []any{count, 2 * count}, row.Values)
+	}
+	assert.Equal(t, int64(100), count)
+	assert.False(t, res.HasNext())
+}
+
+func TestStreamClosedResult(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("UNWIND range(1, 10) AS i RETURN i;")
+	assert.Nil(t, err)
+	rows := res.Stream(context.Background(), 0)
+	row := <-rows
+	assert.Nil(t, row.Err)
+	res.Close()
+	count := 1
+	for row := range rows {
+		assert.Nil(t, row.Err)
+		count++
+	}
+	assert.Less(t, count, 10)
+}
+
+func TestStreamConversionError(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("UNWIND range(1, 10) AS i RETURN i;")
+	assert.Nil(t, err)
+	defer res.Close()
+	RegisterTypeDecoder(TypeInt64, func(value any) (any, error) {
+		if value.(int64) == 3 {
+			return nil, errors.New("three")
+		}
+		return value, nil
+	})
+	t.Cleanup(func() { typeDecoders.Delete(TypeInt64) })
+	var rows []StreamRow
+	for row := range res.Stream(context.Background(), 16) {
+		rows = append(rows, row)
+	}
+	assert.Equal(t, 3, len(rows))
+	assert.Nil(t, rows[1].Err)
+	assert.True(t, errors.Is(rows[2].Err, ErrConversion))
+}
+
+func TestStreamCancel(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("UNWIND range(1, 1000) AS i RETURN i;")
+	assert.Nil(t, err)
+	defer res.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	rows := res.Stream(ctx, 0)
+	row := <-rows
+	assert.Nil(t, row.Err)
+	assert.Equal(t, []any{int64(1)}, row.Values)
+	cancel()
+	count := 1
+	for row := range rows {
+		if row.Err != nil {
+			assert.True(t, errors.Is(row.Err, context.Canceled))
+			assert.True(t, errors.Is(row.Err, ErrInterrupted))
+			continue
+		}
+		count++
+	}
+	assert.Less(t, count, 1000)
+	assert.True(t, res.HasNext())
+}
@@ -0,0 +1,588 @@
+package kuzu
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// tagName is the name of the struct tag used to map Kuzu columns, STRUCT
+// fields and properties to the fields of a Go struct.
+// A tag of "-" excludes the field. Fields without a tag are matched by their
+// name, case-insensitively.
+const tagName = "kuzu"
+
+// durationType is the type of time.Duration, which INTERVAL values can be
+// scanned into.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// timeType is the type of time.Time.
+var timeType = reflect.TypeOf(time.Time{})
+
+// mapItemsType is the type of []MapItem, which MAP values decoded to Go maps
+// can be scanned into.
+var mapItemsType = reflect.TypeOf([]MapItem(nil))
+
+// structField describes an exported field of a Go struct that values can be
+// scanned into.
+type structField struct {
+	name  string
+	index []int
+}
+
+// structFieldsCache caches the fields of the struct types used as scan
+// targets, keyed by reflect.Type.
+var structFieldsCache sync.Map
+
+// structFieldsOf returns the fields of the struct type that can be scanned
+// into, including the fields of embedded structs and exported embedded struct
+// pointers. As in encoding/json, a field hides the fields of the same name
+// embedded more deeply, the fields of the same name at the same depth hide
+// each other unless exactly one of them is tagged, and each embedded type is
+// visited once, so that a type embedding a pointer to itself is supported.
+// The fields are returned in the order they are declared.
+func structFieldsOf(structType reflect.Type) []structField {
+	if cached, ok := structFieldsCache.Load(structType); ok {
+		return cached.([]structField)
+	}
+	type embeddedStruct struct {
+		structType reflect.Type
+		index      []int
+	}
+	type candidate struct {
+		structField
+		depth  int
+		tagged bool
+	}
+	var candidates []candidate
+	current := []embeddedStruct{}
+	next := []embeddedStruct{{structType: structType}}
+	// count and nextCount count the embeddings of the types at the current
+	// and next depths. A type embedded twice at the same depth gives
+	// ambiguous fields.
+	var count, nextCount map[reflect.Type]int
+	visited := map[reflect.Type]bool{}
+	for depth := 0; len(next) > 0; depth++ {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+		for _, embedded := range current {
+			if visited[embedded.structType] {
+				continue
+			}
+			visited[embedded.structType] = true
+			for i := 0; i < embedded.structType.NumField(); i++ {
+				field := embedded.structType.Field(i)
+				tag := field.Tag.Get(tagName)
+				if tag == "-" {
+					continue
+				}
+				index := make([]int, len(embedded.index)+1)
+				copy(index, embedded.index)
+				index[len(embedded.index)] = i
+				if field.Anonymous && tag == "" {
+					embeddedType := field.Type
+					if embeddedType.Kind() == reflect.Ptr {
+						// As in encoding/json, the fields promoted through an
+						// unexported struct pointer are skipped, since the
+						// pointer cannot be allocated through reflection.
+						if !field.IsExported() {
+							continue
+						}
+						embeddedType = embeddedType.Elem()
+					}
+					if embeddedType.Kind() == reflect.Struct {
+						nextCount[embeddedType]++
+						if nextCount[embeddedType] == 1 {
+							next = append(next, embeddedStruct{structType: embeddedType, index: index})
+						}
+						continue
+					}
+				}
+				if !field.IsExported() {
+					continue
+				}
+				name := tag
+				if name == "" {
+					name = field.Name
+				}
+				found := candidate{structField: structField{name: name, index: index}, depth: depth, tagged: tag != ""}
+				candidates = append(candidates, found)
+				if count[embedded.structType] > 1 {
+					candidates = append(candidates, found)
+				}
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].name != candidates[j].name {
+			return candidates[i].name < candidates[j].name
+		}
+		if candidates[i].depth != candidates[j].depth {
+			return candidates[i].depth < candidates[j].depth
+		}
+		return candidates[i].tagged && !candidates[j].tagged
+	})
+	var fields []structField
+	for i := 0; i < len(candidates); {
+		j := i + 1
+		for j < len(candidates) && candidates[j].name == candidates[i].name {
+			j++
+		}
+		dominant := candidates[i]
+		if j-i == 1 || dominant.depth != candidates[i+1].depth || dominant.tagged != candidates[i+1].tagged {
+			fields = append(fields, dominant.structField)
+		}
+		i = j
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return lessIndex(fields[i].index, fields[j].index)
+	})
+	structFieldsCache.Store(structType, fields)
+	return fields
+}
+
+// lessIndex returns true if the field at index a is declared before the field
+// at index b.
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// metadataFields are the names of the node and relationship metadata that
+// Node.Decode and Relationship.Decode store in tagged struct fields.
+var metadataFields = map[string]struct{}{
+	"_id":    {},
+	"_label": {},
+	"_src":   {},
+	"_dst":   {},
+}
+
+// lookupStructField returns the field of the struct type that the name maps
+// to. A field whose tag matches the name exactly takes precedence over a
+// field whose name matches case-insensitively.
+func lookupStructField(structType reflect.Type, name string) (structField, bool) {
+	fields := structFieldsOf(structType)
+	for _, field := range fields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.name, name) {
+			return field, true
+		}
+	}
+	return structField{}, false
+}
+
+// readFieldByIndex returns the field of the struct value at the index. It
+// returns false if an embedded struct pointer on the way is nil, along with
+// the zero value of the field.
+func readFieldByIndex(structValue reflect.Value, index []int) (reflect.Value, bool) {
+	structType := structValue.Type()
+	for i, fieldIndex := range index {
+		if i > 0 && structType.Kind() == reflect.Ptr {
+			structType = structType.Elem()
+			if !structValue.IsValid() || structValue.IsNil() {
+				structValue = reflect.Value{}
+			} else {
+				structValue = structValue.Elem()
+			}
+		}
+		fieldType := structType.Field(fieldIndex).Type
+		if structValue.IsValid() {
+			structValue = structValue.Field(fieldIndex)
+		}
+		structType = fieldType
+	}
+	if !structValue.IsValid() {
+		return reflect.Zero(structType), false
+	}
+	return structValue, true
+}
+
+// fieldByIndex returns the field of the struct value at the index, allocating
+// the embedded struct pointers on the way.
+func fieldByIndex(structValue reflect.Value, index []int) reflect.Value {
+	for i, fieldIndex := range index {
+		if i > 0 && structValue.Kind() == reflect.Ptr {
+			if structValue.IsNil() {
+				structValue.Set(reflect.New(structValue.Type().Elem()))
+			}
+			structValue = structValue.Elem()
+		}
+		structValue = structValue.Field(fieldIndex)
+	}
+	return structValue
+}
+
+// scanInto converts a Go value returned by kuzuValueToGoValue and stores it in
+// the value pointed to by dest, which must be a non-nil pointer. A BLOB is
+// written to dest instead if dest is an io.Writer.
+func scanInto(dest any, value any) error {
+	if writer, ok := dest.(io.Writer); ok {
+		if blob, ok := value.([]byte); ok {
+			_, err := writer.Write(blob)
+			return err
+		}
+	}
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer, got %T", dest)
+	}
+	return assignValue(destValue.Elem(), value)
+}
+
+// assignValue converts a Go value returned by kuzuValueToGoValue to the type
+// of dest and stores it in dest. The decoder registered for the type of dest
+// and the Unmarshaler, sql.Scanner and encoding.TextUnmarshaler interfaces
+// take precedence over the built-in conversions.
+func assignValue(dest reflect.Value, value any) error {
+	if handled, err := decodeInto(dest, value); handled {
+		return err
+	}
+	if value == nil {
+		switch dest.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			dest.Set(reflect.Zero(dest.Type()))
+			return nil
+		}
+		return fmt.Errorf("cannot assign NULL to %s", dest.Type())
+	}
+	src := reflect.ValueOf(value)
+	if dest.Kind() == reflect.Ptr {
+		if src.Type().AssignableTo(dest.Type()) {
+			dest.Set(src)
+			return nil
+		}
+		elem := reflect.New(dest.Type().Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		dest.Set(elem)
+		return nil
+	}
+	if src.Type().AssignableTo(dest.Type()) {
+		dest.Set(src)
+		return nil
+	}
+	switch v := value.(type) {
+	case Node:
+		if dest.Kind() == reflect.Struct {
+			return v.Decode(dest.Addr().Interface())
+		}
+	case Relationship:
+		if dest.Kind() == reflect.Struct {
+			return v.Decode(dest.Addr().Interface())
+		}
+	case map[string]any:
+		switch dest.Kind() {
+		case reflect.Struct:
+			return assignStruct(dest, v)
+		case reflect.Map:
+			return assignMap(dest, len(v), func(yield func(key any, value any) error) error {
+				for key, value := range v {
+					if err := yield(key, value); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+	case []MapItem:
+		if dest.Kind() == reflect.Map {
+			return assignMap(dest, len(v), func(yield func(key any, value any) error) error {
+				for _, item := range v {
+					if err := yield(item.Key, item.Value); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}
+	case []any:
+		switch dest.Kind() {
+		case reflect.Slice:
+			slice := reflect.MakeSlice(dest.Type(), len(v), len(v))
+			for i, elem := range v {
+				if err := assignValue(slice.Index(i), elem); err != nil {
+					return fmt.Errorf("element %d: %w", i, err)
+				}
+			}
+			dest.Set(slice)
+			return nil
+		case reflect.Array:
+			if dest.Len() != len(v) {
+				return fmt.Errorf("cannot assign a list of %d elements to %s", len(v), dest.Type())
+			}
+			for i, elem := range v {
+				if err := assignValue(dest.Index(i), elem); err != nil {
+					return fmt.Errorf("element %d: %w", i, err)
+				}
+			}
+			return nil
+		}
+	case *big.Int:
+		switch dest.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.IsInt64() && !dest.OverflowInt(v.Int64()) {
+				dest.SetInt(v.Int64())
+				return nil
+			}
+			return fmt.Errorf("value %s overflows %s", v.String(), dest.Type())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v.IsUint64() && !dest.OverflowUint(v.Uint64()) {
+				dest.SetUint(v.Uint64())
+				return nil
+			}
+			return fmt.Errorf("value %s overflows %s", v.String(), dest.Type())
+		case reflect.String:
+			dest.SetString(v.String())
+			return nil
+		}
+	case Union:
+		return assignValue(dest, v.Value)
+	case Interval:
+		if dest.Type() == durationType {
+			dest.SetInt(int64(v.Duration()))
+			return nil
+		}
+	case decimal.Decimal:
+		switch dest.Kind() {
+		case reflect.Float32, reflect.Float64:
+			dest.SetFloat(v.InexactFloat64())
+			return nil
+		case reflect.String:
+			dest.SetString(v.String())
+			return nil
+		}
+	case fmt.Stringer:
+		if dest.Kind() == reflect.String {
+			dest.SetString(v.String())
+			return nil
+		}
+	}
+	if src.Kind() == reflect.Map {
+		return assignGoMap(dest, src)
+	}
+	return assignBasic(dest, src)
+}
+
+// assignBasic converts between the numeric, string and byte slice kinds,
+// checking for overflows.
+func assignBasic(dest reflect.Value, src reflect.Value) error {
+	switch dest.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !dest.OverflowInt(src.Int()) {
+				dest.SetInt(src.Int())
+				return nil
+			}
+			return fmt.Errorf("value %d overflows %s", src.Int(), dest.Type())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if src.Uint() <= math.MaxInt64 && !dest.OverflowInt(int64(src.Uint())) {
+				dest.SetInt(int64(src.Uint()))
+				return nil
+			}
+			return fmt.Errorf("value %d overflows %s", src.Uint(), dest.Type())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if src.Int() >= 0 && !dest.OverflowUint(uint64(src.Int())) {
+				dest.SetUint(uint64(src.Int()))
+				return nil
+			}
+			return fmt.Errorf("value %d overflows %s", src.Int(), dest.Type())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if !dest.OverflowUint(src.Uint()) {
+				dest.SetUint(src.Uint())
+				return nil
+			}
+			return fmt.Errorf("value %d overflows %s", src.Uint(), dest.Type())
+		}
+	case reflect.Float32, reflect.Float64:
+		switch src.Kind() {
+		case reflect.Float32, reflect.Float64:
+			dest.SetFloat(src.Float())
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			dest.SetFloat(float64(src.Int()))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			dest.SetFloat(float64(src.Uint()))
+			return nil
+		}
+	case reflect.Bool:
+		if src.Kind() == reflect.Bool {
+			dest.SetBool(src.Bool())
+			return nil
+		}
+	case reflect.String:
+		switch {
+		case src.Kind() == reflect.String:
+			dest.SetString(src.String())
+			return nil
+		case src.Kind() == reflect.Slice && src.Type().Elem().Kind() == reflect.Uint8:
+			dest.SetString(string(src.Bytes()))
+			return nil
+		}
+	case reflect.Slice:
+		if dest.Type().Elem().Kind() == reflect.Uint8 && src.Kind() == reflect.String {
+			dest.SetBytes([]byte(src.String()))
+			return nil
+		}
+	case reflect.Interface:
+		if src.Type().Implements(dest.Type()) {
+			dest.Set(src)
+			return nil
+		}
+	}
+	if src.Type().ConvertibleTo(dest.Type()) && src.Kind() == dest.Kind() {
+		dest.Set(src.Convert(dest.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %s to %s", src.Type(), dest.Type())
+}
+
+// assignStruct stores the fields of a Kuzu STRUCT value in the matching
+// fields of a Go struct. Fields of the STRUCT without a matching Go field are
+// ignored.
+func assignStruct(dest reflect.Value, fields map[string]any) error {
+	for name, value := range fields {
+		field, ok := lookupStructField(dest.Type(), name)
+		if !ok {
+			continue
+		}
+		if err := assignValue(fieldByIndex(dest, field.index), value); err != nil {
+			return fmt.Errorf("field %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// assignMap builds a Go map of the type of dest from the key-value pairs
+// produced by each.
+func assignMap(dest reflect.Value, size int, each func(yield func(key any, value any) error) error) error {
+	mapType := dest.Type()
+	goMap := reflect.MakeMapWithSize(mapType, size)
+	err := each(func(key any, value any) error {
+		goKey := reflect.New(mapType.Key()).Elem()
+		if err := assignValue(goKey, key); err != nil {
+			return fmt.Errorf("map key: %w", err)
+		}
+		goValue := reflect.New(mapType.Elem()).Elem()
+		if err := assignValue(goValue, value); err != nil {
+			return fmt.Errorf("map value for key %v: %w", key, err)
+		}
+		goMap.SetMapIndex(goKey, goValue)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	dest.Set(goMap)
+	return nil
+}
+
+// assignGoMap stores a MAP decoded to a Go map, as with ValueOptions.GoMaps,
+// in a Go map of another type or in a []MapItem.
+func assignGoMap(dest reflect.Value, src reflect.Value) error {
+	switch {
+	case dest.Kind() == reflect.Map:
+		return assignMap(dest, src.Len(), func(yield func(key any, value any) error) error {
+			iter := src.MapRange()
+			for iter.Next() {
+				if err := yield(iter.Key().Interface(), iter.Value().Interface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case dest.Type() == mapItemsType:
+		items := make([]MapItem, 0, src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			items = append(items, MapItem{Key: iter.Key().Interface(), Value: iter.Value().Interface()})
+		}
+		dest.Set(reflect.ValueOf(items))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %s to %s", src.Type(), dest.Type())
+}
+
+// Decode copies the properties of the Node into the fields of the struct
+// pointed to by dest. Properties are mapped to fields as columns are in
+// FlatTuple.ScanStruct. The internal ID and the label of the node are stored
+// in the fields tagged `kuzu:"_id"` and `kuzu:"_label"`, if any.
+func (node Node) Decode(dest any) error {
+	structValue, err := structDestination(dest)
+	if err != nil {
+		return err
+	}
+	return decodeProperties(structValue, node.Properties, map[string]any{
+		"_id":    node.ID,
+		"_label": node.Label,
+	})
+}
+
+// Decode copies the properties of the Relationship into the fields of the
+// struct pointed to by dest. Properties are mapped to fields as columns are in
+// FlatTuple.ScanStruct. The internal ID, the label, and the internal IDs of
+// the source and destination nodes of the relationship are stored in the
+// fields tagged `kuzu:"_id"`, `kuzu:"_label"`, `kuzu:"_src"` and
+// `kuzu:"_dst"`, if any.
+func (relationship Relationship) Decode(dest any) error {
+	structValue, err := structDestination(dest)
+	if err != nil {
+		return err
+	}
+	return decodeProperties(structValue, relationship.Properties, map[string]any{
+		"_id":    relationship.ID,
+		"_label": relationship.Label,
+		"_src":   relationship.SourceID,
+		"_dst":   relationship.DestinationID,
+	})
+}
+
+// structDestination returns the struct pointed to by dest, or an error if dest
+// is not a non-nil pointer to a struct.
+func structDestination(dest any) (reflect.Value, error) {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() || destValue.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, newError(ErrorKindInvalidArgument, "destination must be a non-nil pointer to a struct, got %T", dest)
+	}
+	return destValue.Elem(), nil
+}
+
+// decodeProperties stores the properties of a node or a relationship, and
+// its metadata, in the matching fields of a Go struct. The metadata is only
+// stored in fields explicitly tagged with its name.
+func decodeProperties(dest reflect.Value, properties map[string]any, metadata map[string]any) error {
+	if err := assignStruct(dest, properties); err != nil {
+		return err
+	}
+	for _, field := range structFieldsOf(dest.Type()) {
+		value, ok := metadata[field.name]
+		if !ok {
+			continue
+		}
+		if err := assignValue(fieldByIndex(dest, field.index), value); err != nil {
+			return fmt.Errorf("field %q: %w", field.name, err)
+		}
+	}
+	return nil
+}
@@ -6,35 +6,41 @@ import "C"
 
 import (
 	"math"
+	"math/big"
 	"time"
 )
 
-// unixEpoch returns the Unix epoch time.
-func unixEpoch() time.Time {
-	return time.Unix(0, 0)
-}
+// secondsPerDay is the number of seconds in a day, ignoring leap seconds as
+// Kuzu and Unix time do.
+const secondsPerDay = 24 * 60 * 60
 
-// timeToKuzuDate converts a time.Time to a kuzu_date_t.
+// timeToKuzuDate converts a time.Time to a kuzu_date_t holding the date of the
+// time in UTC. It supports the dates before 1970 and the ones too far from
+// 1970 for their difference to fit in a time.Duration.
 func timeToKuzuDate(inputTime time.Time) C.kuzu_date_t {
-	diff := inputTime.Sub(unixEpoch())
-	diffDays := math.Floor(diff.Hours() / 24)
+	seconds := inputTime.Unix()
+	days := seconds / secondsPerDay
+	// Round towards negative infinity, so that the times before 1970 belong to
+	// the day they are in.
+	if seconds%secondsPerDay < 0 {
+		days--
+	}
 	cKuzuDate := C.kuzu_date_t{}
-	cKuzuDate.days = C.int32_t(diffDays)
+	cKuzuDate.days = C.int32_t(days)
 	return cKuzuDate
 }
 
-// kuzuDateToTime converts a kuzu_date_t to a time.Time in UTC.
+// kuzuDateToTime converts a kuzu_date_t to a time.Time at midnight UTC.
 func kuzuDateToTime(cKuzuDate C.kuzu_date_t) time.Time {
-	diff := time.Duration(cKuzuDate.days) * 24 * time.Hour
-	return unixEpoch().UTC().Add(diff)
+	return time.Unix(int64(cKuzuDate.days)*secondsPerDay, 0).UTC()
 }
 
-// timeToKuzuTimestamp converts a time.Time to a kuzu_timestamp_t.
+// timeToKuzuTimestamp converts a time.Time to a kuzu_timestamp_t. The time is
+// truncated to the microsecond towards negative infinity, so that the times
+// before 1970 are truncated like the other ones.
 func timeToKuzuTimestamp(inputTime time.Time) C.kuzu_timestamp_t {
-	nanoseconds := inputTime.UnixNano()
-	microseconds := nanoseconds / 1000
 	cKuzuTime := C.kuzu_timestamp_t{}
-	cKuzuTime.value = C.int64_t(microseconds)
+	cKuzuTime.value = C.int64_t(inputTime.UnixMicro())
 	return cKuzuTime
 }
 
@@ -60,14 +66,19 @@ func durationToKuzuInterval(inputDuration time.Duration) C.kuzu_interval_t {
 	return cKuzuInterval
 }
 
-// kuzuIntervalToDuration converts a kuzu_interval_t to a time.Duration.
+// kuzuIntervalToDuration converts a kuzu_interval_t to a time.Duration,
+// counting a month as 30 days. An interval beyond the range of a
+// time.Duration, about 292 years, is converted to the maximum or minimum
+// duration, as time.Time.Sub does.
 func kuzuIntervalToDuration(cKuzuInterval C.kuzu_interval_t) time.Duration {
-	days := cKuzuInterval.days
-	months := cKuzuInterval.months
-	microseconds := cKuzuInterval.micros
-	totalDays := int64(days) + int64(months)*30
-	totalSeconds := totalDays * 24 * 60 * 60
-	totalMicroseconds := totalSeconds*1000000 + int64(microseconds)
-	totalNanoseconds := totalMicroseconds * 1000
-	return time.Duration(totalNanoseconds)
+	totalDays := int64(cKuzuInterval.days) + int64(cKuzuInterval.months)*30
+	total := new(big.Int).Mul(big.NewInt(totalDays), big.NewInt(int64(24*time.Hour)))
+	total.Add(total, new(big.Int).Mul(big.NewInt(int64(cKuzuInterval.micros)), big.NewInt(int64(time.Microsecond))))
+	if !total.IsInt64() {
+		if total.Sign() > 0 {
+			return time.Duration(math.MaxInt64)
+		}
+		return time.Duration(math.MinInt64)
+	}
+	return time.Duration(total.Int64())
 }
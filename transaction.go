@@ -0,0 +1,189 @@
+package kuzu
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNestedTransaction is returned by Begin when the connection already has an
+// active transaction. Kuzu does not support nested transactions. It is an Error
+// of kind ErrorKindRuntime.
+var ErrNestedTransaction = &Error{Kind: ErrorKindRuntime, Message: "a transaction is already active on the connection"}
+
+// ErrTransactionDone is returned by the methods of Transaction once the
+// transaction has been committed or rolled back. It is an Error of kind
+// ErrorKindClosed.
+var ErrTransactionDone = &Error{Kind: ErrorKindClosed, Message: "the transaction has already been committed or rolled back"}
+
+// TransactionOptions represents the options used to begin a transaction.
+// ReadOnly is a boolean flag to begin a read-only transaction, in which any
+// write query fails.
+type TransactionOptions struct {
+	ReadOnly bool
+}
+
+// Transaction represents an active transaction on a Connection.
+// Transaction is returned by the `Begin` method of Connection. All the queries
+// executed through the transaction run on the underlying connection until
+// `Commit` or `Rollback` is called.
+type Transaction struct {
+	connection *Connection
+	readOnly   bool
+	isDone     bool
+}
+
+// Begin begins a transaction on the connection with the specified options.
+// It returns ErrNestedTransaction if the connection already has an active
+// transaction.
+func (conn *Connection) Begin(opts TransactionOptions) (*Transaction, error) {
+	return conn.beginContext(context.Background(), opts)
+}
+
+// WithTransaction runs fn inside a read-write transaction on the connection.
+// The transaction is committed if fn returns nil, and rolled back if fn
+// returns an error or panics. The panic is propagated after the rollback.
+// If the context is done before the transaction is committed, the transaction
+// is rolled back and the returned error wraps ctx.Err().
+func (conn *Connection) WithTransaction(ctx context.Context, fn func(tx *Transaction) error) (err error) {
+	tx, err := conn.beginContext(ctx, TransactionOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		}
+	}()
+	if err = fn(tx); err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, ErrTransactionDone) {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// beginContext begins a transaction on the connection, watching the context
+// while the BEGIN statement runs.
+func (conn *Connection) beginContext(ctx context.Context, opts TransactionOptions) (*Transaction, error) {
+	if err := conn.lock(); err != nil {
+		return nil, err
+	}
+	defer conn.mu.Unlock()
+	if conn.transaction != nil {
+		return nil, ErrNestedTransaction
+	}
+	query := "BEGIN TRANSACTION"
+	if opts.ReadOnly {
+		query = "BEGIN TRANSACTION READ ONLY"
+	}
+	queryResult, err := conn.queryContext(ctx, query)
+	if queryResult != nil {
+		queryResult.Close()
+	}
+	if err != nil {
+		return nil, wrapError(err, "failed to begin transaction")
+	}
+	tx := &Transaction{connection: conn, readOnly: opts.ReadOnly}
+	conn.transaction = tx
+	return tx, nil
+}
+
+// IsReadOnly returns true if the transaction was begun in read-only mode.
+func (tx *Transaction) IsReadOnly() bool {
+	return tx.readOnly
+}
+
+// Query executes the specified query string inside the transaction and
+// returns the result.
+func (tx *Transaction) Query(query string) (*QueryResult, error) {
+	return tx.QueryContext(context.Background(), query)
+}
+
+// QueryContext executes the specified query string inside the transaction and
+// returns the result. The query is interrupted if the context is done.
+func (tx *Transaction) QueryContext(ctx context.Context, query string) (*QueryResult, error) {
+	if err := tx.lock(); err != nil {
+		return nil, err
+	}
+	defer tx.connection.mu.Unlock()
+	return tx.connection.queryContext(ctx, query)
+}
+
+// Execute executes the specified prepared statement with the specified
+// arguments inside the transaction and returns the result.
+func (tx *Transaction) Execute(preparedStatement *PreparedStatement, args map[string]any) (*QueryResult, error) {
+	return tx.ExecuteContext(context.Background(), preparedStatement, args)
+}
+
+// ExecuteContext executes the specified prepared statement with the specified
+// arguments inside the transaction and returns the result. The query is
+// interrupted if the context is done.
+func (tx *Transaction) ExecuteContext(ctx context.Context, preparedStatement *PreparedStatement, args map[string]any) (*QueryResult, error) {
+	if err := tx.lock(); err != nil {
+		return nil, err
+	}
+	defer tx.connection.mu.Unlock()
+	return tx.connection.executeContext(ctx, preparedStatement, args)
+}
+
+// Commit commits the transaction.
+func (tx *Transaction) Commit() error {
+	return tx.finish("COMMIT")
+}
+
+// Rollback rolls back the transaction.
+func (tx *Transaction) Rollback() error {
+	return tx.finish("ROLLBACK")
+}
+
+// finish ends the transaction with the specified statement. If a COMMIT
+// fails, the transaction is rolled back so that no transaction is left active
+// on the connection. The transaction is considered done once the statements
+// have run, even if they failed, so that the connection does not refuse new
+// transactions afterwards.
+func (tx *Transaction) finish(query string) error {
+	if err := tx.lock(); err != nil {
+		return err
+	}
+	defer tx.connection.mu.Unlock()
+	err := tx.run(query)
+	if err != nil && query == "COMMIT" {
+		// Kuzu may already have rolled back the transaction, in which case
+		// the ROLLBACK fails as well and its error is of no interest.
+		_ = tx.run("ROLLBACK")
+	}
+	tx.isDone = true
+	if tx.connection.transaction == tx {
+		tx.connection.transaction = nil
+	}
+	return err
+}
+
+// run executes the specified statement on the connection and discards its
+// result. The statement lock must be held.
+func (tx *Transaction) run(query string) error {
+	queryResult, err := tx.connection.query(query)
+	if queryResult != nil {
+		queryResult.Close()
+	}
+	return err
+}
+
+// lock acquires the statement lock of the underlying connection. It returns
+// an error, without holding the lock, if the transaction is done or the
+// connection is closed.
+func (tx *Transaction) lock() error {
+	if err := tx.connection.lock(); err != nil {
+		return err
+	}
+	if tx.isDone {
+		tx.connection.mu.Unlock()
+		return ErrTransactionDone
+	}
+	return nil
+}
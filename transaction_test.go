@@ -0,0 +1,151 @@
+package kuzu
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func openTransactionTestConnection(t *testing.T) *Connection {
+	t.Helper()
+	db, err := OpenInMemoryDatabase(DefaultSystemConfig())
+	assert.Nil(t, err)
+	t.Cleanup(db.Close)
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	t.Cleanup(conn.Close)
+	_, err = conn.Query("CREATE NODE TABLE person(name STRING, age INT64, PRIMARY KEY(name));")
+	assert.Nil(t, err)
+	return conn
+}
+
+func countPersons(t *testing.T, conn *Connection) int64 {
+	t.Helper()
+	res, err := conn.Query("MATCH (a:person) RETURN COUNT(*);")
+	assert.Nil(t, err)
+	defer res.Close()
+	tuple, err := res.Next()
+	assert.Nil(t, err)
+	value, err := tuple.GetValue(0)
+	assert.Nil(t, err)
+	return value.(int64)
+}
+
+func TestTransactionCommit(t *testing.T) {
+	conn := openTransactionTestConnection(t)
+	tx, err := conn.Begin(TransactionOptions{})
+	assert.Nil(t, err)
+	assert.False(t, tx.IsReadOnly())
+	_, err = tx.Query("CREATE (:person {name: 'Alice', age: 30});")
+	assert.Nil(t, err)
+	stmt, err := conn.Prepare("CREATE (:person {name: $name, age: $age});")
+	assert.Nil(t, err)
+	_, err = tx.Execute(stmt, map[string]any{"name": "Bob", "age": int64(40)})
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Commit())
+	assert.Equal(t, int64(2), countPersons(t, conn))
+	assert.ErrorIs(t, tx.Commit(), ErrTransactionDone)
+	_, err = tx.Query("RETURN 1;")
+	assert.ErrorIs(t, err, ErrTransactionDone)
+	assert.ErrorIs(t, err, ErrClosed)
+	var kuzuErr *Error
+	assert.True(t, errors.As(err, &kuzuErr))
+	assert.Equal(t, ErrorKindClosed, kuzuErr.Kind)
+}
+
+func TestTransactionRollback(t *testing.T) {
+	conn := openTransactionTestConnection(t)
+	tx, err := conn.Begin(TransactionOptions{})
+	assert.Nil(t, err)
+	_, err = tx.Query("CREATE (:person {name: 'Alice', age: 30});")
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Rollback())
+	assert.Equal(t, int64(0), countPersons(t, conn))
+}
+
+func TestTransactionReadOnly(t *testing.T) {
+	conn := openTransactionTestConnection(t)
+	tx, err := conn.Begin(TransactionOptions{ReadOnly: true})
+	assert.Nil(t, err)
+	assert.True(t, tx.IsReadOnly())
+	_, err = tx.Query("CREATE (:person {name: 'Alice', age: 30});")
+	assert.NotNil(t, err)
+	_ = tx.Rollback()
+}
+
+func TestTransactionNestedBegin(t *testing.T) {
+	conn := openTransactionTestConnection(t)
+	tx, err := conn.Begin(TransactionOptions{})
+	assert.Nil(t, err)
+	_, err = conn.Begin(TransactionOptions{})
+	assert.ErrorIs(t, err, ErrNestedTransaction)
+	var kuzuErr *Error
+	assert.True(t, errors.As(err, &kuzuErr))
+	assert.Equal(t, ErrorKindRuntime, kuzuErr.Kind)
+	assert.Nil(t, tx.Rollback())
+	// A new transaction can be begun once the previous one is done.
+	tx, err = conn.Begin(TransactionOptions{})
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Rollback())
+}
+
+func TestTransactionBeginCancelledContext(t *testing.T) {
+	conn := openTransactionTestConnection(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := conn.beginContext(ctx, TransactionOptions{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to begin transaction")
+	assert.ErrorIs(t, err, ErrInterrupted)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWithTransactionCommit(t *testing.T) {
+	conn := openTransactionTestConnection(t)
+	err := conn.WithTransaction(context.Background(), func(tx *Transaction) error {
+		_, err := tx.Query("CREATE (:person {name: 'Alice', age: 30});")
+		return err
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), countPersons(t, conn))
+}
+
+func TestWithTransactionError(t *testing.T) {
+	conn := openTransactionTestConnection(t)
+	errAbort := errors.New("abort")
+	err := conn.WithTransaction(context.Background(), func(tx *Transaction) error {
+		_, err := tx.Query("CREATE (:person {name: 'Alice', age: 30});")
+		assert.Nil(t, err)
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+	assert.Equal(t, int64(0), countPersons(t, conn))
+}
+
+func TestWithTransactionPanic(t *testing.T) {
+	conn := openTransactionTestConnection(t)
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = conn.WithTransaction(context.Background(), func(tx *Transaction) error {
+			_, err := tx.Query("CREATE (:person {name: 'Alice', age: 30});")
+			assert.Nil(t, err)
+			panic("boom")
+		})
+	})
+	assert.Equal(t, int64(0), countPersons(t, conn))
+	assert.Nil(t, conn.transaction)
+}
+
+func TestWithTransactionCancelledContext(t *testing.T) {
+	conn := openTransactionTestConnection(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	err := conn.WithTransaction(ctx, func(tx *Transaction) error {
+		_, err := tx.Query("CREATE (:person {name: 'Alice', age: 30});")
+		assert.Nil(t, err)
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int64(0), countPersons(t, conn))
+}
@@ -0,0 +1,265 @@
+package kuzu
+
+// #include "kuzu.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Date wraps a time.Time to bind it as a DATE parameter. Only the year, month
+// and day of the time in its location are kept.
+type Date time.Time
+
+// TimestampTZ wraps a time.Time to bind it as a TIMESTAMP_TZ parameter with
+// microsecond precision.
+type TimestampTZ time.Time
+
+// TimestampNs wraps a time.Time to bind it as a TIMESTAMP_NS parameter.
+type TimestampNs time.Time
+
+// TimestampMs wraps a time.Time to bind it as a TIMESTAMP_MS parameter. The
+// time is truncated to the millisecond.
+type TimestampMs time.Time
+
+// TimestampSec wraps a time.Time to bind it as a TIMESTAMP_SEC parameter. The
+// time is truncated to the second.
+type TimestampSec time.Time
+
+// Int128 wraps a big.Int to bind it as an INT128 parameter, e.g.
+// kuzu.Int128{big.NewInt(42)}. A nil big.Int is bound as NULL.
+type Int128 struct {
+	*big.Int
+}
+
+// Decimal wraps a decimal.Decimal to bind it as a DECIMAL(Precision, Scale)
+// parameter. The value is rounded to Scale decimal places, and binding fails
+// if it does not fit in Precision digits. A decimal.Decimal that is not
+// wrapped is bound as a DECIMAL of the precision and scale of its digits.
+// The C API cannot create DECIMAL values, which the connection creates with a
+// query instead, so a Decimal or a decimal.Decimal can only be the value of a
+// parameter. Binding one nested in a LIST, MAP or STRUCT fails with an error
+// matching ErrConversion; it can be passed as a STRING and cast in the query
+// instead, e.g. with list_transform($list, s -> CAST(s AS DECIMAL(10, 2))).
+type Decimal struct {
+	Value     decimal.Decimal
+	Precision int32
+	Scale     int32
+}
+
+// maxDecimalPrecision is the maximum precision of a DECIMAL in Kuzu.
+const maxDecimalPrecision = 38
+
+// goDateToKuzuValue converts a Date to a kuzu_value of type DATE.
+func goDateToKuzuValue(date Date) *C.kuzu_value {
+	year, month, day := time.Time(date).Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return C.kuzu_value_create_date(timeToKuzuDate(midnight))
+}
+
+// minTimestampNs and maxTimestampNs are the earliest and latest times that a
+// TIMESTAMP_NS can hold, as a number of nanoseconds since 1970 in an int64.
+var (
+	minTimestampNs = time.Unix(0, math.MinInt64)
+	maxTimestampNs = time.Unix(0, math.MaxInt64)
+)
+
+// goTimeToKuzuValue converts a time.Time to a kuzu_value of the specified
+// TIMESTAMP type, or of the type selected as described in
+// ValueOptions.TimestampType if the type is TypeAny.
+func goTimeToKuzuValue(value time.Time, timestampType TypeID) (*C.kuzu_value, error) {
+	inNsRange := !value.Before(minTimestampNs) && !value.After(maxTimestampNs)
+	if timestampType == TypeAny {
+		timestampType = TypeTimestamp
+		if timeHasNanoseconds(value) && inNsRange {
+			timestampType = TypeTimestampNs
+		}
+	}
+	switch timestampType {
+	case TypeTimestamp:
+		return C.kuzu_value_create_timestamp(timeToKuzuTimestamp(value)), nil
+	case TypeTimestampTz:
+		cTimestamp := C.kuzu_timestamp_tz_t{value: C.int64_t(value.UnixMicro())}
+		return C.kuzu_value_create_timestamp_tz(cTimestamp), nil
+	case TypeTimestampNs:
+		if !inNsRange {
+			return nil, newError(ErrorKindConversion, "time %s overflows TIMESTAMP_NS", value)
+		}
+		return C.kuzu_value_create_timestamp_ns(timeToKuzuTimestampNs(value)), nil
+	case TypeTimestampMs:
+		cTimestamp := C.kuzu_timestamp_ms_t{value: C.int64_t(value.UnixMilli())}
+		return C.kuzu_value_create_timestamp_ms(cTimestamp), nil
+	case TypeTimestampSec:
+		cTimestamp := C.kuzu_timestamp_sec_t{value: C.int64_t(value.Unix())}
+		return C.kuzu_value_create_timestamp_sec(cTimestamp), nil
+	}
+	return nil, newError(ErrorKindConversion, "cannot bind a time.Time as %s", timestampType)
+}
+
+// bigIntToInt128 converts a big.Int to a kuzu_int128_t. It returns an error if
+// the value does not fit in 128 bits.
+func bigIntToInt128(value *big.Int) (C.kuzu_int128_t, error) {
+	var result C.kuzu_int128_t
+	minInt128 := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+	if value.BitLen() > 127 && value.Cmp(minInt128) != 0 {
+		return result, newError(ErrorKindConversion, "value %s overflows INT128", value)
+	}
+	// The two's complement of a negative value is its offset from 2^128.
+	twosComplement := new(big.Int).Set(value)
+	if value.Sign() < 0 {
+		twosComplement.Add(twosComplement, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	var bytes [16]byte
+	twosComplement.FillBytes(bytes[:])
+	result.high = C.int64_t(binary.BigEndian.Uint64(bytes[:8]))
+	result.low = C.uint64_t(binary.BigEndian.Uint64(bytes[8:]))
+	return result, nil
+}
+
+// goBigIntToKuzuValue converts a big.Int to a kuzu_value of type INT128.
+func goBigIntToKuzuValue(value *big.Int) (*C.kuzu_value, error) {
+	cInt128, err := bigIntToInt128(value)
+	if err != nil {
+		return nil, err
+	}
+	return C.kuzu_value_create_int128(cInt128), nil
+}
+
+// goUUIDToKuzuValue converts a UUID to a kuzu_value of type UUID. The C API
+// has no constructor for UUID values, so a NULL UUID value is created and the
+// 128-bit integer Kuzu stores for the UUID is copied into it. Kuzu flips the
+// most significant bit of that integer so that UUIDs sort like their string
+// representations.
+func goUUIDToKuzuValue(value uuid.UUID) *C.kuzu_value {
+	var cUUID C.kuzu_int128_t
+	cUUID.high = C.int64_t(binary.BigEndian.Uint64(value[:8]) ^ (1 << 63))
+	cUUID.low = C.uint64_t(binary.BigEndian.Uint64(value[8:]))
+	int128Value := C.kuzu_value_create_int128(cUUID)
+	defer C.kuzu_value_destroy(int128Value)
+	var uuidType C.kuzu_logical_type
+	C.kuzu_data_type_create(C.KUZU_UUID, nil, 0, &uuidType)
+	defer C.kuzu_data_type_destroy(&uuidType)
+	kuzuValue := C.kuzu_value_create_null_with_data_type(&uuidType)
+	C.kuzu_value_copy(kuzuValue, int128Value)
+	return kuzuValue
+}
+
+// errDecimalNested is returned when a Decimal is converted to a kuzu_value
+// other than as the value of a parameter.
+var errDecimalNested = errors.New("a DECIMAL can only be passed as the value of a parameter")
+
+// goDecimalToKuzuValue converts a decimal.Decimal to a kuzu_value of type
+// DECIMAL, whose precision and scale are the smallest ones holding the
+// decimal exactly, as a Decimal with them is.
+func goDecimalToKuzuValue(value decimal.Decimal) (*C.kuzu_value, error) {
+	scale := max(-value.Exponent(), 0)
+	precision := max(int32(value.NumDigits()), scale)
+	if value.Exponent() > 0 {
+		precision += value.Exponent()
+	}
+	return goTypedDecimalToKuzuValue(Decimal{Value: value, Precision: precision, Scale: scale})
+}
+
+// goTypedDecimalToKuzuValue converts a Decimal to a kuzu_value after rounding
+// it to its scale and checking that it fits in its precision. The C API cannot
+// create DECIMAL values, so the connection converts the decimal from its
+// string representation with CAST.
+func goTypedDecimalToKuzuValue(value Decimal) (*C.kuzu_value, error) {
+	if value.Precision < 1 || value.Precision > maxDecimalPrecision {
+		return nil, newError(ErrorKindConversion, "the precision of a DECIMAL must be between 1 and %d, got %d", maxDecimalPrecision, value.Precision)
+	}
+	if value.Scale < 0 || value.Scale > value.Precision {
+		return nil, newError(ErrorKindConversion, "the scale of a DECIMAL must be between 0 and its precision %d, got %d", value.Precision, value.Scale)
+	}
+	rounded := value.Value.Round(value.Scale)
+	integerDigits := rounded.Truncate(0).Abs()
+	maxInteger := decimal.New(1, value.Precision-value.Scale)
+	if integerDigits.GreaterThanOrEqual(maxInteger) {
+		return nil, newError(ErrorKindConversion, "value %s overflows DECIMAL(%d, %d)", value.Value, value.Precision, value.Scale)
+	}
+	return nil, &queriedValueError{
+		query: fmt.Sprintf("RETURN CAST($value AS DECIMAL(%d, %d))", value.Precision, value.Scale),
+		value: rounded.StringFixed(value.Scale),
+		err:   errDecimalNested,
+	}
+}
+
+// TypedValue wraps a value to bind it as a parameter of the specified Kuzu
+// type instead of the type inferred from the value. It allows binding empty
+// lists, maps and structs, and converting numbers to the numeric type of the
+// elements, e.g. a []int to an INT32[]. The numbers are converted with the
+// same overflow checks as in FlatTuple.Scan.
+// A list is bound to a LIST or an ARRAY type, a []MapItem or a Go map to a
+// MAP type, a map[string]any to a STRUCT type, whose fields missing from the
+// map are NULL, and a Union to a UNION type, as described in Union.
+type TypedValue struct {
+	Type  LogicalType
+	Value any
+}
+
+// TypedList wraps a slice or an array to bind it as a LIST of the specified
+// element type, even if it is empty.
+func TypedList(elementType LogicalType, values any) TypedValue {
+	return TypedValue{
+		Type:  LogicalType{ID: TypeList, Children: []LogicalType{elementType}},
+		Value: values,
+	}
+}
+
+// TypedMap wraps a []MapItem or a Go map to bind it as a MAP of the specified
+// key and value types, even if it is empty.
+func TypedMap(keyType LogicalType, valueType LogicalType, items any) TypedValue {
+	return TypedValue{
+		Type:  LogicalType{ID: TypeMap, Children: []LogicalType{keyType, valueType}},
+		Value: items,
+	}
+}
+
+// Null represents a value of type T that may be NULL, as sql.Null does for
+// database/sql. Valid is false if the value is NULL, in which case V is the
+// zero value of T. A Null can be scanned from any value that can be scanned
+// into a T, including the ones nested in lists, structs and the properties of
+// nodes and relationships, while scanning NULL into a T fails unless T is a
+// pointer, an interface, a map or a slice. It can also be passed as a
+// parameter, and is bound as a NULL of the Kuzu type of T if it is not Valid.
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+// UnmarshalKuzu implements Unmarshaler.
+func (n *Null[T]) UnmarshalKuzu(value any) error {
+	if value == nil {
+		*n = Null[T]{}
+		return nil
+	}
+	if err := assignValue(reflect.ValueOf(&n.V).Elem(), value); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Scan implements sql.Scanner, so that a Null can also be used with the
+// database/sql driver.
+func (n *Null[T]) Scan(value any) error {
+	return n.UnmarshalKuzu(value)
+}
+
+// MarshalKuzu implements Marshaler.
+func (n Null[T]) MarshalKuzu() (any, error) {
+	if !n.Valid {
+		return (*T)(nil), nil
+	}
+	return n.V, nil
+}
@@ -0,0 +1,571 @@
+package kuzu
+
+// #include "kuzu.h"
+// #include <stdlib.h>
+// #include <string.h>
+//
+// static kuzu_data_type_id kuzu_go_value_type_id(kuzu_value* value) {
+//     kuzu_logical_type logical_type;
+//     kuzu_value_get_data_type(value, &logical_type);
+//     kuzu_data_type_id id = kuzu_data_type_get_id(&logical_type);
+//     kuzu_data_type_destroy(&logical_type);
+//     return id;
+// }
+//
+// typedef struct {
+//     kuzu_state state;
+//     int64_t value;
+// } kuzu_go_int64_result;
+//
+// // kuzu_go_value_get_int64 returns an integer of at most 64 bits, the bits of
+// // a UINT64, the days of a DATE or the value of a TIMESTAMP as an int64.
+// static kuzu_go_int64_result kuzu_go_value_get_int64(kuzu_value* value, kuzu_data_type_id id) {
+//     kuzu_go_int64_result result = {KuzuError, 0};
+//     switch (id) {
+//     case KUZU_INT64:
+//     case KUZU_SERIAL: {
+//         result.state = kuzu_value_get_int64(value, &result.value);
+//         break;
+//     }
+//     case KUZU_INT32: {
+//         int32_t v;
+//         result.state = kuzu_value_get_int32(value, &v);
+//         result.value = v;
+//         break;
+//     }
+//     case KUZU_INT16: {
+//         int16_t v;
+//         result.state = kuzu_value_get_int16(value, &v);
+//         result.value = v;
+//         break;
+//     }
+//     case KUZU_INT8: {
+//         int8_t v;
+//         result.state = kuzu_value_get_int8(value, &v);
+//         result.value = v;
+//         break;
+//     }
+//     case KUZU_UINT64: {
+//         uint64_t v;
+//         result.state = kuzu_value_get_uint64(value, &v);
+//         result.value = (int64_t)v;
+//         break;
+//     }
+//     case KUZU_UINT32: {
+//         uint32_t v;
+//         result.state = kuzu_value_get_uint32(value, &v);
+//         result.value = v;
+//         break;
+//     }
+//     case KUZU_UINT16: {
+//         uint16_t v;
+//         result.state = kuzu_value_get_uint16(value, &v);
+//         result.value = v;
+//         break;
+//     }
+//     case KUZU_UINT8: {
+//         uint8_t v;
+//         result.state = kuzu_value_get_uint8(value, &v);
+//         result.value = v;
+//         break;
+//     }
+//     case KUZU_DATE: {
+//         kuzu_date_t v;
+//         result.state = kuzu_value_get_date(value, &v);
+//         result.value = v.days;
+//         break;
+//     }
+//     case KUZU_TIMESTAMP: {
+//         kuzu_timestamp_t v;
+//         result.state = kuzu_value_get_timestamp(value, &v);
+//         result.value = v.value;
+//         break;
+//     }
+//     case KUZU_TIMESTAMP_NS: {
+//         kuzu_timestamp_ns_t v;
+//         result.state = kuzu_value_get_timestamp_ns(value, &v);
+//         result.value = v.value;
+//         break;
+//     }
+//     case KUZU_TIMESTAMP_MS: {
+//         kuzu_timestamp_ms_t v;
+//         result.state = kuzu_value_get_timestamp_ms(value, &v);
+//         result.value = v.value;
+//         break;
+//     }
+//     case KUZU_TIMESTAMP_SEC: {
+//         kuzu_timestamp_sec_t v;
+//         result.state = kuzu_value_get_timestamp_sec(value, &v);
+//         result.value = v.value;
+//         break;
+//     }
+//     case KUZU_TIMESTAMP_TZ: {
+//         kuzu_timestamp_tz_t v;
+//         result.state = kuzu_value_get_timestamp_tz(value, &v);
+//         result.value = v.value;
+//         break;
+//     }
+//     default:
+//         break;
+//     }
+//     return result;
+// }
+//
+// typedef struct {
+//     kuzu_state state;
+//     double value;
+// } kuzu_go_double_result;
+//
+// static kuzu_go_double_result kuzu_go_value_get_double(kuzu_value* value, kuzu_data_type_id id) {
+//     kuzu_go_double_result result = {KuzuError, 0};
+//     if (id == KUZU_DOUBLE) {
+//         result.state = kuzu_value_get_double(value, &result.value);
+//     } else if (id == KUZU_FLOAT) {
+//         float v;
+//         result.state = kuzu_value_get_float(value, &v);
+//         result.value = v;
+//     }
+//     return result;
+// }
+//
+// typedef struct {
+//     kuzu_state state;
+//     bool value;
+// } kuzu_go_bool_result;
+//
+// static kuzu_go_bool_result kuzu_go_value_get_bool(kuzu_value* value) {
+//     kuzu_go_bool_result result = {KuzuError, false};
+//     result.state = kuzu_value_get_bool(value, &result.value);
+//     return result;
+// }
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+// Value is a lazy view of a value of a FlatTuple. Unlike GetValue, which
+// converts a whole value to Go, a Value only reads the parts that are
+// accessed: Index and Field return views of the elements of a LIST or ARRAY
+// and of the fields of a STRUCT, NODE or REL without reading them, and the
+// typed accessors such as Int64 and String convert a single value without
+// allocating maps or slices for its parents. The accessors of the numeric,
+// BOOL and time values of a column do not allocate at all.
+// A Value is read from its FlatTuple on every access, so it can no longer be
+// used once the FlatTuple or its QueryResult is closed. A view of an element
+// or a field that does not exist is only reported by its accessors. The
+// children of a NULL value are NULL.
+type Value struct {
+	tuple  *FlatTuple
+	column uint64
+	path   []valuePathStep
+}
+
+// valuePathStep is a step from a value to one of its children: the element
+// at index of a LIST or ARRAY, or the field with the specified name.
+type valuePathStep struct {
+	index   uint64
+	field   string
+	isField bool
+}
+
+// View returns a lazy view of the value at the given index in the FlatTuple.
+func (tuple *FlatTuple) View(index uint64) Value {
+	return Value{tuple: tuple, column: index}
+}
+
+// Index returns a view of the element at the given index of a LIST or ARRAY.
+func (value Value) Index(index int) Value {
+	return value.child(valuePathStep{index: uint64(index)})
+}
+
+// Field returns a view of the named field of a STRUCT, or of the named
+// property of a NODE or REL.
+func (value Value) Field(name string) Value {
+	return value.child(valuePathStep{field: name, isField: true})
+}
+
+// child returns a view of the child of the value at the specified step.
+func (value Value) child(step valuePathStep) Value {
+	// The full slice expression makes append copy the path, so that views
+	// sharing a parent do not overwrite the steps of each other.
+	path := append(value.path[:len(value.path):len(value.path)], step)
+	return Value{tuple: value.tuple, column: value.column, path: path}
+}
+
+// read locks the FlatTuple of the value and returns the C value, or nil if
+// the value or one of its parents is NULL, with its type. If it returns no
+// error, the caller must call release with the returned children once it is
+// done with the C value.
+func (value Value) read() (*C.kuzu_value, LogicalType, []C.kuzu_value, error) {
+	tuple := value.tuple
+	if err := tuple.lock(); err != nil {
+		return nil, LogicalType{}, nil, err
+	}
+	// The value of the column is read into the FlatTuple, which is locked,
+	// rather than into a local variable that would escape to the heap on
+	// every read.
+	if tuple.cValue == nil {
+		tuple.cValue = new(C.kuzu_value)
+	}
+	status := C.kuzu_flat_tuple_get_value(&tuple.cFlatTuple, C.uint64_t(value.column), tuple.cValue)
+	if status != C.KuzuSuccess {
+		tuple.unlock()
+		return nil, LogicalType{}, nil, newError(ErrorKindConversion, "failed to get value with status: %d", status)
+	}
+	logicalType := LogicalType{}
+	if columnTypes := tuple.queryResult.getColumnTypes(); value.column < uint64(len(columnTypes)) {
+		logicalType = columnTypes[value.column]
+	}
+	current := tuple.cValue
+	if len(value.path) == 0 {
+		return nullAsNil(current), logicalType, nil, nil
+	}
+	// The children of a value may refer to it, so they are all destroyed
+	// by release.
+	children := make([]C.kuzu_value, 0, len(value.path))
+	for _, step := range value.path {
+		if C.kuzu_value_is_null(current) {
+			return nil, LogicalType{}, children, nil
+		}
+		children = append(children, C.kuzu_value{})
+		if err := kuzuValueChild(current, step, &children[len(children)-1]); err != nil {
+			value.release(children[:len(children)-1])
+			return nil, LogicalType{}, nil, err
+		}
+		current = &children[len(children)-1]
+		if step.isField {
+			logicalType = logicalType.field(step.field)
+		} else {
+			logicalType = logicalType.child(0)
+		}
+	}
+	return nullAsNil(current), logicalType, children, nil
+}
+
+// release destroys the children returned by read and unlocks the FlatTuple.
+func (value Value) release(children []C.kuzu_value) {
+	for i := len(children) - 1; i >= 0; i-- {
+		C.kuzu_value_destroy(&children[i])
+	}
+	value.tuple.unlock()
+}
+
+// nullAsNil returns nil if kuzuValue is NULL and kuzuValue otherwise.
+func nullAsNil(kuzuValue *C.kuzu_value) *C.kuzu_value {
+	if C.kuzu_value_is_null(kuzuValue) {
+		return nil
+	}
+	return kuzuValue
+}
+
+// kuzuValueChild gets the child of kuzuValue at the specified step into out.
+func kuzuValueChild(kuzuValue *C.kuzu_value, step valuePathStep, out *C.kuzu_value) error {
+	typeID := kuzuValueTypeID(kuzuValue)
+	var status C.kuzu_state
+	switch {
+	case !step.isField && (typeID == TypeList || typeID == TypeArray):
+		if step.index >= kuzuListSize(kuzuValue, typeID) {
+			return newError(ErrorKindConversion, "index %d out of range for %s value", step.index, typeID)
+		}
+		status = C.kuzu_value_get_list_element(kuzuValue, C.uint64_t(step.index), out)
+	case step.isField && typeID == TypeStruct:
+		index, ok := kuzuFieldIndex(kuzuValue, TypeStruct, step.field)
+		if !ok {
+			return newError(ErrorKindConversion, "STRUCT value has no field %q", step.field)
+		}
+		status = C.kuzu_value_get_struct_field_value(kuzuValue, index, out)
+	case step.isField && typeID == TypeNode:
+		index, ok := kuzuFieldIndex(kuzuValue, TypeNode, step.field)
+		if !ok {
+			return newError(ErrorKindConversion, "NODE value has no property %q", step.field)
+		}
+		status = C.kuzu_node_val_get_property_value_at(kuzuValue, index, out)
+	case step.isField && typeID == TypeRel:
+		index, ok := kuzuFieldIndex(kuzuValue, TypeRel, step.field)
+		if !ok {
+			return newError(ErrorKindConversion, "REL value has no property %q", step.field)
+		}
+		status = C.kuzu_rel_val_get_property_value_at(kuzuValue, index, out)
+	case step.isField:
+		return newError(ErrorKindConversion, "cannot get field %q of %s value", step.field, typeID)
+	default:
+		return newError(ErrorKindConversion, "cannot get element %d of %s value", step.index, typeID)
+	}
+	if status != C.KuzuSuccess {
+		return newError(ErrorKindConversion, "failed to get child value with status: %d", status)
+	}
+	return nil
+}
+
+// kuzuFieldIndex returns the index of the named field of a STRUCT value, or
+// of the named property of a NODE or REL value.
+func kuzuFieldIndex(kuzuValue *C.kuzu_value, typeID TypeID, name string) (C.uint64_t, bool) {
+	var size C.uint64_t
+	switch typeID {
+	case TypeStruct:
+		C.kuzu_value_get_struct_num_fields(kuzuValue, &size)
+	case TypeNode:
+		C.kuzu_node_val_get_property_size(kuzuValue, &size)
+	case TypeRel:
+		C.kuzu_rel_val_get_property_size(kuzuValue, &size)
+	}
+	var currentName *C.char
+	for i := C.uint64_t(0); i < size; i++ {
+		var status C.kuzu_state
+		switch typeID {
+		case TypeStruct:
+			status = C.kuzu_value_get_struct_field_name(kuzuValue, i, &currentName)
+		case TypeNode:
+			status = C.kuzu_node_val_get_property_name_at(kuzuValue, i, &currentName)
+		case TypeRel:
+			status = C.kuzu_rel_val_get_property_name_at(kuzuValue, i, &currentName)
+		}
+		if status != C.KuzuSuccess {
+			return 0, false
+		}
+		// The name is compared in place to avoid copying it to Go.
+		found := unsafe.String((*byte)(unsafe.Pointer(currentName)), int(C.strlen(currentName))) == name
+		C.kuzu_destroy_string(currentName)
+		if found {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// kuzuValueTypeID returns the ID of the type of kuzuValue.
+func kuzuValueTypeID(kuzuValue *C.kuzu_value) TypeID {
+	return TypeID(C.kuzu_go_value_type_id(kuzuValue))
+}
+
+// kuzuListSize returns the number of elements of a LIST or ARRAY value.
+func kuzuListSize(kuzuValue *C.kuzu_value, typeID TypeID) uint64 {
+	var size C.uint64_t
+	if typeID == TypeArray {
+		var cLogicalType C.kuzu_logical_type
+		defer C.kuzu_data_type_destroy(&cLogicalType)
+		C.kuzu_value_get_data_type(kuzuValue, &cLogicalType)
+		C.kuzu_data_type_get_num_elements_in_array(&cLogicalType, &size)
+	} else {
+		C.kuzu_value_get_list_size(kuzuValue, &size)
+	}
+	return uint64(size)
+}
+
+// nullValueError returns the error returned when a NULL value is read as a
+// Go type that cannot represent it.
+func nullValueError(goType string) error {
+	return newError(ErrorKindConversion, "cannot get NULL value as %s", goType)
+}
+
+// IsNull returns true if the value is NULL.
+func (value Value) IsNull() (bool, error) {
+	kuzuValue, _, children, err := value.read()
+	if err != nil {
+		return false, err
+	}
+	defer value.release(children)
+	return kuzuValue == nil, nil
+}
+
+// Type returns the ID of the type of the value. It returns TypeAny if the
+// value is NULL.
+func (value Value) Type() (TypeID, error) {
+	kuzuValue, _, children, err := value.read()
+	if err != nil {
+		return TypeAny, err
+	}
+	defer value.release(children)
+	if kuzuValue == nil {
+		return TypeAny, nil
+	}
+	return kuzuValueTypeID(kuzuValue), nil
+}
+
+// Len returns the number of elements of a LIST or ARRAY, of fields of a
+// STRUCT, of properties of a NODE or REL, or of items of a MAP. It returns 0
+// if the value is NULL.
+func (value Value) Len() (int, error) {
+	kuzuValue, _, children, err := value.read()
+	if err != nil {
+		return 0, err
+	}
+	defer value.release(children)
+	if kuzuValue == nil {
+		return 0, nil
+	}
+	var size C.uint64_t
+	var status C.kuzu_state
+	switch typeID := kuzuValueTypeID(kuzuValue); typeID {
+	case TypeList, TypeArray:
+		size = C.uint64_t(kuzuListSize(kuzuValue, typeID))
+	case TypeStruct:
+		status = C.kuzu_value_get_struct_num_fields(kuzuValue, &size)
+	case TypeNode:
+		status = C.kuzu_node_val_get_property_size(kuzuValue, &size)
+	case TypeRel:
+		status = C.kuzu_rel_val_get_property_size(kuzuValue, &size)
+	case TypeMap:
+		status = C.kuzu_value_get_map_size(kuzuValue, &size)
+	default:
+		return 0, newError(ErrorKindConversion, "cannot get length of %s value", typeID)
+	}
+	if status != C.KuzuSuccess {
+		return 0, newError(ErrorKindConversion, "failed to get length with status: %d", status)
+	}
+	return int(size), nil
+}
+
+// Any converts the value and all of its children to Go as GetValue does.
+func (value Value) Any() (any, error) {
+	kuzuValue, logicalType, children, err := value.read()
+	if err != nil {
+		return nil, err
+	}
+	defer value.release(children)
+	if kuzuValue == nil {
+		return nil, nil
+	}
+	return kuzuValueToGoValue(*kuzuValue, logicalType, value.tuple.queryResult.valueOptions)
+}
+
+// Bool returns the value of a BOOL. It returns an error matching
+// ErrConversion if the value is NULL or of another type, as the other typed
+// accessors do.
+func (value Value) Bool() (bool, error) {
+	kuzuValue, _, children, err := value.read()
+	if err != nil {
+		return false, err
+	}
+	defer value.release(children)
+	if kuzuValue == nil {
+		return false, nullValueError("bool")
+	}
+	if typeID := kuzuValueTypeID(kuzuValue); typeID != TypeBool {
+		return false, newError(ErrorKindConversion, "cannot get %s value as bool", typeID)
+	}
+	result := C.kuzu_go_value_get_bool(kuzuValue)
+	if result.state != C.KuzuSuccess {
+		return false, newError(ErrorKindConversion, "failed to get bool value with status: %d", result.state)
+	}
+	return bool(result.value), nil
+}
+
+// Int64 returns the value of a signed or unsigned integer of at most 64 bits
+// or of a SERIAL. A UINT64 greater than math.MaxInt64 returns an error.
+func (value Value) Int64() (int64, error) {
+	kuzuValue, _, children, err := value.read()
+	if err != nil {
+		return 0, err
+	}
+	defer value.release(children)
+	if kuzuValue == nil {
+		return 0, nullValueError("int64")
+	}
+	typeID := kuzuValueTypeID(kuzuValue)
+	switch typeID {
+	case TypeInt64, TypeSerial, TypeInt32, TypeInt16, TypeInt8, TypeUint64, TypeUint32, TypeUint16, TypeUint8:
+	default:
+		return 0, newError(ErrorKindConversion, "cannot get %s value as int64", typeID)
+	}
+	result := C.kuzu_go_value_get_int64(kuzuValue, C.kuzu_data_type_id(typeID))
+	if result.state != C.KuzuSuccess {
+		return 0, newError(ErrorKindConversion, "failed to get %s value with status: %d", typeID, result.state)
+	}
+	if typeID == TypeUint64 && result.value < 0 {
+		return 0, newError(ErrorKindConversion, "UINT64 value %d overflows int64", uint64(result.value))
+	}
+	return int64(result.value), nil
+}
+
+// Float64 returns the value of a DOUBLE or FLOAT.
+func (value Value) Float64() (float64, error) {
+	kuzuValue, _, children, err := value.read()
+	if err != nil {
+		return 0, err
+	}
+	defer value.release(children)
+	if kuzuValue == nil {
+		return 0, nullValueError("float64")
+	}
+	typeID := kuzuValueTypeID(kuzuValue)
+	if typeID != TypeDouble && typeID != TypeFloat {
+		return 0, newError(ErrorKindConversion, "cannot get %s value as float64", typeID)
+	}
+	result := C.kuzu_go_value_get_double(kuzuValue, C.kuzu_data_type_id(typeID))
+	if result.state != C.KuzuSuccess {
+		return 0, newError(ErrorKindConversion, "failed to get %s value with status: %d", typeID, result.state)
+	}
+	return float64(result.value), nil
+}
+
+// String returns the value of a STRING, or the string representation of a
+// UUID.
+func (value Value) String() (string, error) {
+	kuzuValue, _, children, err := value.read()
+	if err != nil {
+		return "", err
+	}
+	defer value.release(children)
+	if kuzuValue == nil {
+		return "", nullValueError("string")
+	}
+	var cString *C.char
+	var status C.kuzu_state
+	switch typeID := kuzuValueTypeID(kuzuValue); typeID {
+	case TypeString:
+		status = C.kuzu_value_get_string(kuzuValue, &cString)
+	case TypeUUID:
+		status = C.kuzu_value_get_uuid(kuzuValue, &cString)
+	default:
+		return "", newError(ErrorKindConversion, "cannot get %s value as string", typeID)
+	}
+	if status != C.KuzuSuccess {
+		return "", newError(ErrorKindConversion, "failed to get string value with status: %d", status)
+	}
+	defer C.kuzu_destroy_string(cString)
+	return C.GoString(cString), nil
+}
+
+// Time returns the value of a DATE or of a TIMESTAMP of any precision, in the
+// location selected by the ValueOptions of the connection.
+func (value Value) Time() (time.Time, error) {
+	kuzuValue, _, children, err := value.read()
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer value.release(children)
+	if kuzuValue == nil {
+		return time.Time{}, nullValueError("time.Time")
+	}
+	return kuzuTimeValueToGoTime(kuzuValue, kuzuValueTypeID(kuzuValue), value.tuple.queryResult.valueOptions)
+}
+
+// kuzuTimeValueToGoTime converts a non-NULL kuzu_value of a DATE or TIMESTAMP
+// type to a time.Time in the location selected by options.
+func kuzuTimeValueToGoTime(kuzuValue *C.kuzu_value, typeID TypeID, options ValueOptions) (time.Time, error) {
+	switch typeID {
+	case TypeDate, TypeTimestamp, TypeTimestampTz, TypeTimestampNs, TypeTimestampMs, TypeTimestampSec:
+	default:
+		return time.Time{}, newError(ErrorKindConversion, "cannot get %s value as time.Time", typeID)
+	}
+	result := C.kuzu_go_value_get_int64(kuzuValue, C.kuzu_data_type_id(typeID))
+	if result.state != C.KuzuSuccess {
+		return time.Time{}, newError(ErrorKindConversion, "failed to get %s value with status: %d", typeID, result.state)
+	}
+	value := int64(result.value)
+	switch typeID {
+	case TypeDate:
+		return options.date(kuzuDateToTime(C.kuzu_date_t{days: C.int32_t(value)})), nil
+	case TypeTimestamp, TypeTimestampTz:
+		return options.timestamp(time.UnixMicro(value), typeID), nil
+	case TypeTimestampNs:
+		return options.timestamp(time.Unix(0, value), typeID), nil
+	case TypeTimestampMs:
+		return options.timestamp(time.UnixMilli(value), typeID), nil
+	default:
+		return options.timestamp(time.Unix(value, 0), typeID), nil
+	}
+}
@@ -6,8 +6,10 @@ package kuzu
 import "C"
 
 import (
-	"fmt"
+	"encoding/binary"
+	"io"
 	"reflect"
+	"slices"
 	"sort"
 	"time"
 	"unsafe"
@@ -57,19 +59,39 @@ type MapItem struct {
 	Value any
 }
 
+// Union represents a Kuzu UNION value: Tag is the name of the active member
+// and Value its value.
+// A Union is returned for UNION values. The C API only exposes the value of
+// the active member, so its tag is found from the member types in the schema
+// of the query result: the tag of the first member of the type of the value is
+// used if several members have the same type, and the tag is empty if no
+// member type is known. For example, the tag of a UNION(a INT64, b INT64) is
+// always "a".
+// A Union can also be passed as a parameter. The C API cannot create UNION
+// values, so it is bound as its Value, which Kuzu converts to the first member
+// of the same type when it is stored in or compared to a UNION. The Tag of a
+// Union is checked against the member its Value selects if the Union is
+// wrapped in a TypedValue of the UNION type, whose Value is then converted to
+// the type of the member as in TypedValue. Otherwise, Tag must be empty, since
+// it could not be checked.
+type Union struct {
+	Tag   string
+	Value any
+}
+
 // kuzuNodeValueToGoValue converts a kuzu_value representing a node to a Node
 // struct in Go.
-func kuzuNodeValueToGoValue(kuzuValue C.kuzu_value) (Node, error) {
+func kuzuNodeValueToGoValue(kuzuValue C.kuzu_value, logicalType LogicalType, options ValueOptions) (Node, error) {
 	node := Node{}
 	node.Properties = make(map[string]any)
 	idValue := C.kuzu_value{}
 	C.kuzu_node_val_get_id_val(&kuzuValue, &idValue)
-	nodeId, _ := kuzuValueToGoValue(idValue)
+	nodeId, _ := kuzuValueToGoValue(idValue, LogicalType{}, options)
 	node.ID = nodeId.(InternalID)
 	C.kuzu_value_destroy(&idValue)
 	labelValue := C.kuzu_value{}
 	C.kuzu_node_val_get_label_val(&kuzuValue, &labelValue)
-	nodeLabel, _ := kuzuValueToGoValue(labelValue)
+	nodeLabel, _ := kuzuValueToGoValue(labelValue, LogicalType{}, options)
 	node.Label = nodeLabel.(string)
 	C.kuzu_value_destroy(&labelValue)
 	var propertySize C.uint64_t
@@ -82,7 +104,7 @@ func kuzuNodeValueToGoValue(kuzuValue C.kuzu_value) (Node, error) {
 		keyString := C.GoString(currentKey)
 		C.kuzu_destroy_string(currentKey)
 		C.kuzu_node_val_get_property_value_at(&kuzuValue, i, &currentVal)
-		value, err := kuzuValueToGoValue(currentVal)
+		value, err := kuzuValueToGoValue(currentVal, logicalType.field(keyString), options)
 		if err != nil {
 			errors = append(errors, err)
 		}
@@ -90,32 +112,32 @@ func kuzuNodeValueToGoValue(kuzuValue C.kuzu_value) (Node, error) {
 		C.kuzu_value_destroy(&currentVal)
 	}
 	if len(errors) > 0 {
-		return node, fmt.Errorf("failed to get values: %v", errors)
+		return node, newError(ErrorKindConversion, "failed to get values: %v", errors)
 	}
 	return node, nil
 }
 
 // kuzuRelValueToGoValue converts a kuzu_value representing a relationship to a
 // Relationship struct in Go.
-func kuzuRelValueToGoValue(kuzuValue C.kuzu_value) (Relationship, error) {
+func kuzuRelValueToGoValue(kuzuValue C.kuzu_value, logicalType LogicalType, options ValueOptions) (Relationship, error) {
 	relation := Relationship{}
 	relation.Properties = make(map[string]any)
 	idValue := C.kuzu_value{}
 	C.kuzu_rel_val_get_id_val(&kuzuValue, &idValue)
-	id, _ := kuzuValueToGoValue(idValue)
+	id, _ := kuzuValueToGoValue(idValue, LogicalType{}, options)
 	relation.ID = id.(InternalID)
 	C.kuzu_value_destroy(&idValue)
 	C.kuzu_rel_val_get_src_id_val(&kuzuValue, &idValue)
-	src, _ := kuzuValueToGoValue(idValue)
+	src, _ := kuzuValueToGoValue(idValue, LogicalType{}, options)
 	relation.SourceID = src.(InternalID)
 	C.kuzu_value_destroy(&idValue)
 	C.kuzu_rel_val_get_dst_id_val(&kuzuValue, &idValue)
-	dst, _ := kuzuValueToGoValue(idValue)
+	dst, _ := kuzuValueToGoValue(idValue, LogicalType{}, options)
 	relation.DestinationID = dst.(InternalID)
 	C.kuzu_value_destroy(&idValue)
 	labelValue := C.kuzu_value{}
 	C.kuzu_rel_val_get_label_val(&kuzuValue, &labelValue)
-	label, _ := kuzuValueToGoValue(labelValue)
+	label, _ := kuzuValueToGoValue(labelValue, LogicalType{}, options)
 	relation.Label = label.(string)
 	C.kuzu_value_destroy(&labelValue)
 	var propertySize C.uint64_t
@@ -128,7 +150,7 @@ func kuzuRelValueToGoValue(kuzuValue C.kuzu_value) (Relationship, error) {
 		keyString := C.GoString(currentKey)
 		C.kuzu_destroy_string(currentKey)
 		C.kuzu_rel_val_get_property_value_at(&kuzuValue, i, &currentVal)
-		value, err := kuzuValueToGoValue(currentVal)
+		value, err := kuzuValueToGoValue(currentVal, logicalType.field(keyString), options)
 		if err != nil {
 			errors = append(errors, err)
 		}
@@ -136,22 +158,22 @@ func kuzuRelValueToGoValue(kuzuValue C.kuzu_value) (Relationship, error) {
 		C.kuzu_value_destroy(&currentVal)
 	}
 	if len(errors) > 0 {
-		return relation, fmt.Errorf("failed to get values: %v", errors)
+		return relation, newError(ErrorKindConversion, "failed to get values: %v", errors)
 	}
 	return relation, nil
 }
 
 // kuzuRecursiveRelValueToGoValue converts a kuzu_value representing a recursive
 // relationship to a RecursiveRelationship struct in Go.
-func kuzuRecursiveRelValueToGoValue(kuzuValue C.kuzu_value) (RecursiveRelationship, error) {
+func kuzuRecursiveRelValueToGoValue(kuzuValue C.kuzu_value, logicalType LogicalType, options ValueOptions) (RecursiveRelationship, error) {
 	var nodesVal C.kuzu_value
 	var relsVal C.kuzu_value
 	C.kuzu_value_get_recursive_rel_node_list(&kuzuValue, &nodesVal)
 	C.kuzu_value_get_recursive_rel_rel_list(&kuzuValue, &relsVal)
 	defer C.kuzu_value_destroy(&nodesVal)
 	defer C.kuzu_value_destroy(&relsVal)
-	nodes, _ := kuzuListValueToGoValue(nodesVal)
-	rels, _ := kuzuListValueToGoValue(relsVal)
+	nodes, _ := kuzuListValueToGoValue(nodesVal, logicalType.field("_NODES"), options)
+	rels, _ := kuzuListValueToGoValue(relsVal, logicalType.field("_RELS"), options)
 	recursiveRel := RecursiveRelationship{}
 	recursiveRel.Nodes = make([]Node, len(nodes))
 	for i, n := range nodes {
@@ -167,7 +189,7 @@ func kuzuRecursiveRelValueToGoValue(kuzuValue C.kuzu_value) (RecursiveRelationsh
 
 // kuzuListValueToGoValue converts a kuzu_value representing a LIST or ARRAY to
 // a slice of any in Go.
-func kuzuListValueToGoValue(kuzuValue C.kuzu_value) ([]any, error) {
+func kuzuListValueToGoValue(kuzuValue C.kuzu_value, logicalType LogicalType, options ValueOptions) ([]any, error) {
 	var listSize C.uint64_t
 	cLogicalType := C.kuzu_logical_type{}
 	defer C.kuzu_data_type_destroy(&cLogicalType)
@@ -183,7 +205,7 @@ func kuzuListValueToGoValue(kuzuValue C.kuzu_value) ([]any, error) {
 	var errors []error
 	for i := C.uint64_t(0); i < listSize; i++ {
 		C.kuzu_value_get_list_element(&kuzuValue, i, &currentVal)
-		value, err := kuzuValueToGoValue(currentVal)
+		value, err := kuzuValueToGoValue(currentVal, logicalType.child(0), options)
 		if err != nil {
 			errors = append(errors, err)
 		}
@@ -191,14 +213,14 @@ func kuzuListValueToGoValue(kuzuValue C.kuzu_value) ([]any, error) {
 		C.kuzu_value_destroy(&currentVal)
 	}
 	if len(errors) > 0 {
-		return list, fmt.Errorf("failed to get values: %v", errors)
+		return list, newError(ErrorKindConversion, "failed to get values: %v", errors)
 	}
 	return list, nil
 }
 
 // kuzuStructValueToGoValue converts a kuzu_value representing a STRUCT to a
 // map of string to any in Go.
-func kuzuStructValueToGoValue(kuzuValue C.kuzu_value) (map[string]any, error) {
+func kuzuStructValueToGoValue(kuzuValue C.kuzu_value, logicalType LogicalType, options ValueOptions) (map[string]any, error) {
 	structure := make(map[string]any)
 	var propertySize C.uint64_t
 	C.kuzu_value_get_struct_num_fields(&kuzuValue, &propertySize)
@@ -210,7 +232,7 @@ func kuzuStructValueToGoValue(kuzuValue C.kuzu_value) (map[string]any, error) {
 		keyString := C.GoString(currentKey)
 		C.kuzu_destroy_string(currentKey)
 		C.kuzu_value_get_struct_field_value(&kuzuValue, i, &currentVal)
-		value, err := kuzuValueToGoValue(currentVal)
+		value, err := kuzuValueToGoValue(currentVal, logicalType.field(keyString), options)
 		if err != nil {
 			errors = append(errors, err)
 		}
@@ -218,14 +240,14 @@ func kuzuStructValueToGoValue(kuzuValue C.kuzu_value) (map[string]any, error) {
 		C.kuzu_value_destroy(&currentVal)
 	}
 	if len(errors) > 0 {
-		return structure, fmt.Errorf("failed to get values: %v", errors)
+		return structure, newError(ErrorKindConversion, "failed to get values: %v", errors)
 	}
 	return structure, nil
 }
 
 // kuzuMapValueToGoValue converts a kuzu_value representing a MAP to a
 // slice of MapItem in Go.
-func kuzuMapValueToGoValue(kuzuValue C.kuzu_value) ([]MapItem, error) {
+func kuzuMapValueToGoValue(kuzuValue C.kuzu_value, logicalType LogicalType, options ValueOptions) ([]MapItem, error) {
 	var mapSize C.uint64_t
 	C.kuzu_value_get_map_size(&kuzuValue, &mapSize)
 	mapItems := make([]MapItem, 0, int(mapSize))
@@ -235,11 +257,11 @@ func kuzuMapValueToGoValue(kuzuValue C.kuzu_value) ([]MapItem, error) {
 	for i := C.uint64_t(0); i < mapSize; i++ {
 		C.kuzu_value_get_map_key(&kuzuValue, i, &currentKey)
 		C.kuzu_value_get_map_value(&kuzuValue, i, &currentValue)
-		key, err := kuzuValueToGoValue(currentKey)
+		key, err := kuzuValueToGoValue(currentKey, logicalType.child(0), options)
 		if err != nil {
 			errors = append(errors, err)
 		}
-		value, err := kuzuValueToGoValue(currentValue)
+		value, err := kuzuValueToGoValue(currentValue, logicalType.child(1), options)
 		if err != nil {
 			errors = append(errors, err)
 		}
@@ -248,68 +270,223 @@ func kuzuMapValueToGoValue(kuzuValue C.kuzu_value) ([]MapItem, error) {
 		mapItems = append(mapItems, MapItem{Key: key, Value: value})
 	}
 	if len(errors) > 0 {
-		return mapItems, fmt.Errorf("failed to get values: %v", errors)
+		return mapItems, newError(ErrorKindConversion, "failed to get values: %v", errors)
 	}
 	return mapItems, nil
 }
 
-// kuzuValueToGoValue converts a kuzu_value to a corresponding Go value.
-func kuzuValueToGoValue(kuzuValue C.kuzu_value) (any, error) {
+// kuzuUnionValueToGoValue converts a kuzu_value representing a UNION to a
+// Union in Go.
+func kuzuUnionValueToGoValue(kuzuValue C.kuzu_value, logicalType LogicalType, options ValueOptions) (Union, error) {
+	// The first field of a UNION is its tag, followed by its members. The tag
+	// field holds the value of the active member, whose type is the type of
+	// that member.
+	var numFields C.uint64_t
+	C.kuzu_value_get_struct_num_fields(&kuzuValue, &numFields)
+	memberNames := make([]string, 0, int(numFields))
+	var currentName *C.char
+	for i := C.uint64_t(1); i < numFields; i++ {
+		C.kuzu_value_get_struct_field_name(&kuzuValue, i, &currentName)
+		memberNames = append(memberNames, C.GoString(currentName))
+		C.kuzu_destroy_string(currentName)
+	}
+	var memberValue C.kuzu_value
+	status := C.kuzu_value_get_struct_field_value(&kuzuValue, 0, &memberValue)
+	if status != C.KuzuSuccess {
+		return Union{}, newError(ErrorKindConversion, "failed to get UNION value with status: %d", status)
+	}
+	defer C.kuzu_value_destroy(&memberValue)
+	var memberType C.kuzu_logical_type
+	C.kuzu_value_get_data_type(&memberValue, &memberType)
+	memberTypeID := TypeID(C.kuzu_data_type_get_id(&memberType))
+	C.kuzu_data_type_destroy(&memberType)
+	union := Union{}
+	if index := unionMemberIndex(memberNames, memberTypeID, logicalType); index >= 0 {
+		union.Tag = memberNames[index]
+	}
+	value, err := kuzuValueToGoValue(memberValue, logicalType.field(union.Tag), options)
+	union.Value = value
+	return union, err
+}
+
+// unionMemberIndex returns the index of the first of the members of a UNION
+// whose type has the specified ID in logicalType, or -1 if there is none. The
+// member of a UNION with a single member is found without its type. The
+// members of the same type cannot be told apart, since the C API exposes
+// neither the tag of a UNION value nor the index of its active member.
+func unionMemberIndex(memberNames []string, id TypeID, logicalType LogicalType) int {
+	if len(memberNames) == 1 {
+		return 0
+	}
+	// The member types are read from the Arrow schema, which exports some
+	// types as others.
+	if arrowID, ok := arrowTypeIDs[id]; ok {
+		id = arrowID
+	}
+	for i, name := range memberNames {
+		if logicalType.field(name).ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// mapItemsToGoMap converts the items of a MAP to a Go map of the type of their
+// keys and values, as described in ValueOptions.GoMaps. It returns the items
+// unchanged if they cannot be stored in a Go map.
+func mapItemsToGoMap(items []MapItem) any {
+	if len(items) == 0 || items[0].Key == nil {
+		return items
+	}
+	keyType := reflect.TypeOf(items[0].Key)
+	if !isGoMapKeyType(keyType) {
+		return items
+	}
+	var valueType reflect.Type
+	hasNull := false
+	for _, item := range items {
+		if item.Key == nil || reflect.TypeOf(item.Key) != keyType {
+			return items
+		}
+		if item.Value == nil {
+			hasNull = true
+			continue
+		}
+		if valueType == nil {
+			valueType = reflect.TypeOf(item.Value)
+		} else if reflect.TypeOf(item.Value) != valueType {
+			return items
+		}
+	}
+	if valueType == nil {
+		return items
+	}
+	if hasNull {
+		switch valueType.Kind() {
+		case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
+		default:
+			return items
+		}
+	}
+	goMap := reflect.MakeMapWithSize(reflect.MapOf(keyType, valueType), len(items))
+	for _, item := range items {
+		value := reflect.Zero(valueType)
+		if item.Value != nil {
+			value = reflect.ValueOf(item.Value)
+		}
+		goMap.SetMapIndex(reflect.ValueOf(item.Key), value)
+	}
+	return goMap.Interface()
+}
+
+// isGoMapKeyType returns true if the values of the Go type returned for a Kuzu
+// value can be used as keys of a Go map, i.e. if they are compared by value.
+// Pointer types like *big.Int and the types containing pointers, such as
+// decimal.Decimal, are excluded, except for time.Time, whose values all share
+// the same location.
+func isGoMapKeyType(keyType reflect.Type) bool {
+	switch keyType.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Array:
+		return isGoMapKeyType(keyType.Elem())
+	case reflect.Struct:
+		if keyType == timeType {
+			return true
+		}
+		for i := 0; i < keyType.NumField(); i++ {
+			if !isGoMapKeyType(keyType.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// kuzuValueToGoValue converts a kuzu_value to a corresponding Go value, using
+// the decoder registered for its type with RegisterTypeDecoder, if any.
+// logicalType is the type of the value as described by the Arrow schema of the
+// query result, which the C API does not expose for nested types. It is used
+// to find the active member of UNION values and may be the zero LogicalType
+// when it is unknown.
+// A NULL value is converted to nil whatever its type, since the C getters
+// return the zero value of the type for NULL. All the nested values are
+// converted by this function, so this also applies to them.
+func kuzuValueToGoValue(kuzuValue C.kuzu_value, logicalType LogicalType, options ValueOptions) (any, error) {
 	if C.kuzu_value_is_null(&kuzuValue) {
 		return nil, nil
 	}
-	var logicalType C.kuzu_logical_type
-	defer C.kuzu_data_type_destroy(&logicalType)
-	C.kuzu_value_get_data_type(&kuzuValue, &logicalType)
-	logicalTypeId := C.kuzu_data_type_get_id(&logicalType)
+	var cLogicalType C.kuzu_logical_type
+	defer C.kuzu_data_type_destroy(&cLogicalType)
+	C.kuzu_value_get_data_type(&kuzuValue, &cLogicalType)
+	logicalTypeId := C.kuzu_data_type_get_id(&cLogicalType)
+	value, err := kuzuValueToBuiltinGoValue(kuzuValue, logicalTypeId, logicalType, options)
+	if err != nil {
+		return value, err
+	}
+	value, err = decodeTypeValue(TypeID(logicalTypeId), value)
+	if err != nil {
+		return nil, newError(ErrorKindConversion, "failed to decode %s value: %w", TypeID(logicalTypeId), err)
+	}
+	return value, nil
+}
+
+// kuzuValueToBuiltinGoValue converts a non-NULL kuzu_value of the specified
+// type to the default Go value for the type. logicalType is described in
+// kuzuValueToGoValue.
+func kuzuValueToBuiltinGoValue(kuzuValue C.kuzu_value, logicalTypeId C.kuzu_data_type_id, logicalType LogicalType, options ValueOptions) (any, error) {
 	switch logicalTypeId {
 	case C.KUZU_BOOL:
 		var value C.bool
 		status := C.kuzu_value_get_bool(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get bool value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get bool value with status: %d", status)
 		}
 		return bool(value), nil
 	case C.KUZU_INT64, C.KUZU_SERIAL:
 		var value C.int64_t
 		status := C.kuzu_value_get_int64(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get int64 value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get int64 value with status: %d", status)
 		}
 		return int64(value), nil
 	case C.KUZU_INT32:
 		var value C.int32_t
 		status := C.kuzu_value_get_int32(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get int32 value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get int32 value with status: %d", status)
 		}
 		return int32(value), nil
 	case C.KUZU_INT16:
 		var value C.int16_t
 		status := C.kuzu_value_get_int16(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get int16 value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get int16 value with status: %d", status)
 		}
 		return int16(value), nil
 	case C.KUZU_INT128:
 		var value C.kuzu_int128_t
 		status := C.kuzu_value_get_int128(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get int128 value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get int128 value with status: %d", status)
 		}
-		return int128ToBigInt(value)
+		return int128ToBigInt(value), nil
 	case C.KUZU_INT8:
 		var value C.int8_t
 		status := C.kuzu_value_get_int8(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get int8 value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get int8 value with status: %d", status)
 		}
 		return int8(value), nil
 	case C.KUZU_UUID:
 		var value *C.char
 		status := C.kuzu_value_get_uuid(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get uuid value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get uuid value with status: %d", status)
 		}
 		defer C.kuzu_destroy_string(value)
 		uuidString := C.GoString(value)
@@ -318,176 +495,135 @@ func kuzuValueToGoValue(kuzuValue C.kuzu_value) (any, error) {
 		var value C.uint64_t
 		status := C.kuzu_value_get_uint64(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get uint64 value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get uint64 value with status: %d", status)
 		}
 		return uint64(value), nil
 	case C.KUZU_UINT32:
 		var value C.uint32_t
 		status := C.kuzu_value_get_uint32(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get uint32 value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get uint32 value with status: %d", status)
 		}
 		return uint32(value), nil
 	case C.KUZU_UINT16:
 		var value C.uint16_t
 		status := C.kuzu_value_get_uint16(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get uint16 value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get uint16 value with status: %d", status)
 		}
 		return uint16(value), nil
 	case C.KUZU_UINT8:
 		var value C.uint8_t
 		status := C.kuzu_value_get_uint8(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get uint8 value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get uint8 value with status: %d", status)
 		}
 		return uint8(value), nil
 	case C.KUZU_DOUBLE:
 		var value C.double
 		status := C.kuzu_value_get_double(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get double value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get double value with status: %d", status)
 		}
 		return float64(value), nil
 	case C.KUZU_FLOAT:
 		var value C.float
 		status := C.kuzu_value_get_float(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get float value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get float value with status: %d", status)
 		}
 		return float32(value), nil
 	case C.KUZU_STRING:
 		var outString *C.char
 		status := C.kuzu_value_get_string(&kuzuValue, &outString)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get string value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get string value with status: %d", status)
 		}
 		defer C.kuzu_destroy_string(outString)
 		return C.GoString(outString), nil
-	case C.KUZU_TIMESTAMP:
-		var value C.kuzu_timestamp_t
-		status := C.kuzu_value_get_timestamp(&kuzuValue, &value)
-		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get timestamp value with status: %d", status)
-		}
-		return time.Unix(0, int64(value.value)*1000), nil
-	case C.KUZU_TIMESTAMP_NS:
-		var value C.kuzu_timestamp_ns_t
-		status := C.kuzu_value_get_timestamp_ns(&kuzuValue, &value)
-		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get timestamp_ns value with status: %d", status)
-		}
-		return time.Unix(0, int64(value.value)), nil
-	case C.KUZU_TIMESTAMP_MS:
-		var value C.kuzu_timestamp_ms_t
-		status := C.kuzu_value_get_timestamp_ms(&kuzuValue, &value)
-		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get timestamp_ms value with status: %d", status)
-		}
-		return time.Unix(0, int64(value.value)*1000000), nil
-	case C.KUZU_TIMESTAMP_SEC:
-		var value C.kuzu_timestamp_sec_t
-		status := C.kuzu_value_get_timestamp_sec(&kuzuValue, &value)
-		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get timestamp_sec value with status: %d", status)
-		}
-		return time.Unix(int64(value.value), 0), nil
-	case C.KUZU_TIMESTAMP_TZ:
-		var value C.kuzu_timestamp_tz_t
-		status := C.kuzu_value_get_timestamp_tz(&kuzuValue, &value)
-		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get timestamp_tz value with status: %d", status)
-		}
-		return time.Unix(0, int64(value.value)*1000), nil
-	case C.KUZU_DATE:
-		var value C.kuzu_date_t
-		status := C.kuzu_value_get_date(&kuzuValue, &value)
-		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get date value with status: %d", status)
-		}
-		return kuzuDateToTime(value), nil
+	case C.KUZU_TIMESTAMP, C.KUZU_TIMESTAMP_NS, C.KUZU_TIMESTAMP_MS, C.KUZU_TIMESTAMP_SEC, C.KUZU_TIMESTAMP_TZ, C.KUZU_DATE:
+		return kuzuTimeValueToGoTime(&kuzuValue, TypeID(logicalTypeId), options)
 	case C.KUZU_INTERVAL:
 		var value C.kuzu_interval_t
 		status := C.kuzu_value_get_interval(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get interval value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get interval value with status: %d", status)
 		}
-		return kuzuIntervalToDuration(value), nil
+		if options.IntervalAsDuration {
+			return kuzuIntervalToDuration(value), nil
+		}
+		return kuzuIntervalToInterval(value), nil
 	case C.KUZU_INTERNAL_ID:
 		var value C.kuzu_internal_id_t
 		status := C.kuzu_value_get_internal_id(&kuzuValue, &value)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get internal_id value with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get internal_id value with status: %d", status)
 		}
 		return InternalID{TableID: uint64(value.table_id), Offset: uint64(value.offset)}, nil
 	case C.KUZU_BLOB:
-		var value *C.uint8_t
-		status := C.kuzu_value_get_blob(&kuzuValue, &value)
-		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get blob value with status: %d", status)
+		blob, err := kuzuBlobValueToGoValue(kuzuValue)
+		if err != nil {
+			return nil, newError(ErrorKindConversion, "failed to get blob value: %w", err)
 		}
-		defer C.kuzu_destroy_blob(value)
-		blobSize := C.strlen((*C.char)(unsafe.Pointer(value)))
-		blob := C.GoBytes(unsafe.Pointer(value), C.int(blobSize))
 		return blob, nil
 	case C.KUZU_NODE:
-		return kuzuNodeValueToGoValue(kuzuValue)
+		return kuzuNodeValueToGoValue(kuzuValue, logicalType, options)
 	case C.KUZU_REL:
-		return kuzuRelValueToGoValue(kuzuValue)
+		return kuzuRelValueToGoValue(kuzuValue, logicalType, options)
 	case C.KUZU_RECURSIVE_REL:
-		return kuzuRecursiveRelValueToGoValue(kuzuValue)
+		return kuzuRecursiveRelValueToGoValue(kuzuValue, logicalType, options)
 	case C.KUZU_LIST, C.KUZU_ARRAY:
-		return kuzuListValueToGoValue(kuzuValue)
-	case C.KUZU_STRUCT, C.KUZU_UNION:
-		return kuzuStructValueToGoValue(kuzuValue)
+		return kuzuListValueToGoValue(kuzuValue, logicalType, options)
+	case C.KUZU_STRUCT:
+		return kuzuStructValueToGoValue(kuzuValue, logicalType, options)
+	case C.KUZU_UNION:
+		return kuzuUnionValueToGoValue(kuzuValue, logicalType, options)
 	case C.KUZU_MAP:
-		return kuzuMapValueToGoValue(kuzuValue)
+		items, err := kuzuMapValueToGoValue(kuzuValue, logicalType, options)
+		if err != nil || !options.GoMaps {
+			return items, err
+		}
+		return mapItemsToGoMap(items), nil
 	case C.KUZU_DECIMAL:
 		var outString *C.char
 		status := C.kuzu_value_get_decimal_as_string(&kuzuValue, &outString)
 		if status != C.KuzuSuccess {
-			return nil, fmt.Errorf("failed to get string value of decimal type with status: %d", status)
+			return nil, newError(ErrorKindConversion, "failed to get string value of decimal type with status: %d", status)
 		}
 		goString := C.GoString(outString)
 		C.kuzu_destroy_string(outString)
 		goDecimal, casting_error := decimal.NewFromString(goString)
 		if casting_error != nil {
-			return nil, fmt.Errorf("failed to convert decimal value with error: %w", casting_error)
+			return nil, newError(ErrorKindConversion, "failed to convert decimal value with error: %w", casting_error)
 		}
 		return goDecimal, casting_error
 	default:
 		valueString := C.kuzu_value_to_string(&kuzuValue)
 		defer C.kuzu_destroy_string(valueString)
-		return C.GoString(valueString), fmt.Errorf("unsupported data type with type id: %d. the value is force-casted to string", logicalTypeId)
+		return C.GoString(valueString), newError(ErrorKindConversion, "unsupported data type with type id: %d. the value is force-casted to string", logicalTypeId)
 	}
 }
 
-// int128ToBigInt converts a kuzu_int128_t to a big.Int in Go.
-func int128ToBigInt(value C.kuzu_int128_t) (*big.Int, error) {
-	var outString *C.char
-	status := C.kuzu_int128_t_to_string(value, &outString)
-	if status != C.KuzuSuccess {
-		return nil, fmt.Errorf("failed to convert int128 to string with status: %d", status)
+// int128ToBigInt converts a kuzu_int128_t to a big.Int in Go. The value is
+// decoded from its two's complement bits, since Kuzu cannot format INT128_MIN
+// as a string.
+func int128ToBigInt(value C.kuzu_int128_t) *big.Int {
+	var bytes [16]byte
+	binary.BigEndian.PutUint64(bytes[:8], uint64(value.high))
+	binary.BigEndian.PutUint64(bytes[8:], uint64(value.low))
+	bigInt := new(big.Int).SetBytes(bytes[:])
+	if value.high < 0 {
+		bigInt.Sub(bigInt, new(big.Int).Lsh(big.NewInt(1), 128))
 	}
-	defer C.kuzu_destroy_string(outString)
-	valueString := C.GoString(outString)
-	bigInt := new(big.Int)
-	_, success := bigInt.SetString(valueString, 10)
-	if !success {
-		return nil, fmt.Errorf("failed to convert string to big.Int")
-	}
-	return bigInt, nil
+	return bigInt
 }
 
 // goMapToKuzuStruct converts a map of string to any to a kuzu_value representing
 // a STRUCT. It returns an error if the map is empty.
-func goMapToKuzuStruct(value map[string]any) (*C.kuzu_value, error) {
-	numFields := C.uint64_t(len(value))
-	if numFields == 0 {
-		return nil, fmt.Errorf("failed to create STRUCT value because the map is empty")
+func goMapToKuzuStruct(value map[string]any, options ValueOptions) (*C.kuzu_value, error) {
+	if len(value) == 0 {
+		return nil, newError(ErrorKindConversion, "failed to create STRUCT value because the map is empty")
 	}
-	fieldNames := make([]*C.char, 0, len(value))
-	fieldValues := make([]*C.kuzu_value, 0, len(value))
 	// Sort the keys to ensure the order is consistent.
 	// This is useful for creating a LIST of STRUCTs because in Kuzu, all the
 	// LIST elements must have the same type (i.e., the same order of fields).
@@ -496,88 +632,491 @@ func goMapToKuzuStruct(value map[string]any) (*C.kuzu_value, error) {
 		sortedKeys = append(sortedKeys, k)
 	}
 	sort.Strings(sortedKeys)
-	for _, k := range sortedKeys {
-		fieldNames = append(fieldNames, C.CString(k))
-		kuzuValue, error := goValueToKuzuValue(value[k])
-		if error != nil {
-			return nil, fmt.Errorf("failed to convert value in the map with error: %w", error)
+	fieldValues := make([]any, len(sortedKeys))
+	for i, k := range sortedKeys {
+		fieldValues[i] = value[k]
+	}
+	kuzuValue, err := createKuzuStruct(sortedKeys, fieldValues, options)
+	if err != nil {
+		return nil, newError(ErrorKindConversion, "failed to convert value in the map with error: %w", err)
+	}
+	return kuzuValue, nil
+}
+
+// goStructToKuzuStruct converts a Go struct to a kuzu_value representing a
+// STRUCT. The fields are mapped as in FlatTuple.ScanStruct, in the order they
+// are declared, and the fields tagged with the name of node or relationship
+// metadata, such as `kuzu:"_id"`, are skipped. The fields of a nil embedded
+// struct pointer are NULL. It returns an error if the struct has no field.
+func goStructToKuzuStruct(structValue reflect.Value, options ValueOptions) (*C.kuzu_value, error) {
+	fields := structFieldsOf(structValue.Type())
+	fieldNames := make([]string, 0, len(fields))
+	fieldValues := make([]any, 0, len(fields))
+	for _, field := range fields {
+		if _, ok := metadataFields[field.name]; ok {
+			continue
 		}
-		fieldValues = append(fieldValues, kuzuValue)
-		defer C.kuzu_value_destroy(kuzuValue)
-		defer C.free(unsafe.Pointer(C.CString(k)))
+		fieldNames = append(fieldNames, field.name)
+		fieldValue, ok := readFieldByIndex(structValue, field.index)
+		if !ok {
+			// Binding a nil pointer to the type of the field creates a typed NULL.
+			fieldValues = append(fieldValues, reflect.Zero(reflect.PointerTo(fieldValue.Type())).Interface())
+			continue
+		}
+		fieldValues = append(fieldValues, fieldValue.Interface())
+	}
+	if len(fieldNames) == 0 {
+		return nil, newError(ErrorKindConversion, "failed to create STRUCT value because %s has no exported field", structValue.Type())
+	}
+	kuzuValue, err := createKuzuStruct(fieldNames, fieldValues, options)
+	if err != nil {
+		return nil, newError(ErrorKindConversion, "failed to convert field of %s with error: %w", structValue.Type(), err)
 	}
+	return kuzuValue, nil
+}
 
+// createKuzuStruct creates a kuzu_value representing a STRUCT with the
+// specified field names and values, in this order.
+func createKuzuStruct(fieldNames []string, fieldValues []any, options ValueOptions) (*C.kuzu_value, error) {
+	cFieldNames := make([]*C.char, 0, len(fieldNames))
+	cFieldValues := make([]*C.kuzu_value, 0, len(fieldValues))
+	for i, name := range fieldNames {
+		kuzuValue, err := goValueToKuzuValue(fieldValues[i], options)
+		if err != nil {
+			return nil, err
+		}
+		defer C.kuzu_value_destroy(kuzuValue)
+		cFieldValues = append(cFieldValues, kuzuValue)
+		cName := C.CString(name)
+		defer C.free(unsafe.Pointer(cName))
+		cFieldNames = append(cFieldNames, cName)
+	}
 	var kuzuValue *C.kuzu_value
-	status := C.kuzu_value_create_struct(numFields, &fieldNames[0], &fieldValues[0], &kuzuValue)
+	status := C.kuzu_value_create_struct(C.uint64_t(len(fieldNames)), &cFieldNames[0], &cFieldValues[0], &kuzuValue)
 	if status != C.KuzuSuccess {
-		return nil, fmt.Errorf("failed to create STRUCT value with status: %d", status)
+		return nil, newError(ErrorKindConversion, "failed to create STRUCT value with status: %d", status)
 	}
 	return kuzuValue, nil
 }
 
+// typeList is an immutable linked list of types.
+type typeList struct {
+	head reflect.Type
+	tail *typeList
+}
+
+// contains returns true if the list contains the specified type.
+func (list *typeList) contains(t reflect.Type) bool {
+	for ; list != nil; list = list.tail {
+		if list.head == t {
+			return true
+		}
+	}
+	return false
+}
+
+// goNullToKuzuValue converts a nil pointer to a kuzu_value representing a NULL
+// of the Kuzu type that the values pointed to are bound as. The NULL is
+// untyped if that type cannot be determined from the zero value, e.g. for an
+// empty slice, or if the type refers to itself, e.g. a struct with a pointer
+// to its own type, whose Kuzu type would be infinite.
+func goNullToKuzuValue(pointerType reflect.Type, options ValueOptions) *C.kuzu_value {
+	elemType := pointerType.Elem()
+	if options.nullTypes.contains(elemType) {
+		return C.kuzu_value_create_null()
+	}
+	options.nullTypes = &typeList{head: elemType, tail: options.nullTypes}
+	zeroValue, err := goValueToKuzuValue(reflect.Zero(elemType).Interface(), options)
+	if err != nil {
+		return C.kuzu_value_create_null()
+	}
+	defer C.kuzu_value_destroy(zeroValue)
+	var logicalType C.kuzu_logical_type
+	C.kuzu_value_get_data_type(zeroValue, &logicalType)
+	defer C.kuzu_data_type_destroy(&logicalType)
+	return C.kuzu_value_create_null_with_data_type(&logicalType)
+}
+
 // goSliceOfMapItemsToKuzuMap converts a slice of MapItem to a kuzu_value
 // representing a MAP. It returns an error if the slice is empty or if the keys
 // in the slice are of different types or if the values in the slice are of
 // different types.
-func goSliceOfMapItemsToKuzuMap(slice []MapItem) (*C.kuzu_value, error) {
-	numItems := C.uint64_t(len(slice))
-	if numItems == 0 {
-		return nil, fmt.Errorf("failed to create MAP value because the slice is empty")
+func goSliceOfMapItemsToKuzuMap(slice []MapItem, options ValueOptions) (*C.kuzu_value, error) {
+	if len(slice) == 0 {
+		return nil, newError(ErrorKindConversion, "failed to create MAP value because the slice is empty, use TypedMap to bind an empty MAP")
 	}
 	keys := make([]*C.kuzu_value, 0, len(slice))
 	values := make([]*C.kuzu_value, 0, len(slice))
 	for _, item := range slice {
-		key, error := goValueToKuzuValue(item.Key)
+		key, error := goValueToKuzuValue(item.Key, options)
 		if error != nil {
-			return nil, fmt.Errorf("failed to convert key in the slice with error: %w", error)
+			return nil, newError(ErrorKindConversion, "failed to convert key in the slice with error: %w", error)
 		}
 		keys = append(keys, key)
 		defer C.kuzu_value_destroy(key)
-		value, error := goValueToKuzuValue(item.Value)
+		value, error := goValueToKuzuValue(item.Value, options)
 		if error != nil {
-			return nil, fmt.Errorf("failed to convert value in the slice with error: %w", error)
+			return nil, newError(ErrorKindConversion, "failed to convert value in the slice with error: %w", error)
 		}
 		values = append(values, value)
 		defer C.kuzu_value_destroy(value)
 	}
+	return createKuzuMap(keys, values)
+}
+
+// goReflectMapToKuzuMap converts a Go map other than a map[string]any, which is
+// bound as a STRUCT, to a kuzu_value representing a MAP, e.g. a
+// map[int64]string to a MAP(INT64, STRING). The items are sorted by key if the
+// keys are numbers or strings, so that the order of the MAP is deterministic.
+// An empty map is bound as an empty MAP of the types of its keys and values.
+func goReflectMapToKuzuMap(mapValue reflect.Value, options ValueOptions) (*C.kuzu_value, error) {
+	if mapValue.Len() == 0 {
+		return goEmptyMapToKuzuValue(mapValue.Type(), options)
+	}
+	keys := mapValue.MapKeys()
+	sortMapKeys(keys)
+	items := make([]MapItem, len(keys))
+	for i, key := range keys {
+		items[i] = MapItem{Key: key.Interface(), Value: mapValue.MapIndex(key).Interface()}
+	}
+	return goSliceOfMapItemsToKuzuMap(items, options)
+}
+
+// sortMapKeys sorts the keys of a Go map if they are numbers or strings.
+func sortMapKeys(keys []reflect.Value) {
+	switch keys[0].Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Int() < keys[j].Int() })
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Uint() < keys[j].Uint() })
+	case reflect.Float32, reflect.Float64:
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Float() < keys[j].Float() })
+	case reflect.String:
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	}
+}
+
+// goEmptyMapToKuzuValue creates an empty MAP of the Kuzu types that the keys
+// and values of the Go map type are bound as. The C API cannot build a MAP
+// type from its key and value types, so the type is taken from a MAP holding
+// the zero key and value.
+func goEmptyMapToKuzuValue(mapType reflect.Type, options ValueOptions) (*C.kuzu_value, error) {
+	if mapType.Key().Kind() == reflect.Interface || mapType.Elem().Kind() == reflect.Interface {
+		return nil, newError(ErrorKindConversion, "failed to create MAP value because the %s is empty, use TypedMap to bind an empty MAP", mapType)
+	}
+	zeroKey, err := goValueToKuzuValue(reflect.Zero(mapType.Key()).Interface(), options)
+	if err != nil {
+		return nil, newError(ErrorKindConversion, "failed to infer the type of the keys of an empty MAP: %w", err)
+	}
+	defer C.kuzu_value_destroy(zeroKey)
+	zeroValue, err := goValueToKuzuValue(reflect.Zero(mapType.Elem()).Interface(), options)
+	if err != nil {
+		return nil, newError(ErrorKindConversion, "failed to infer the type of the values of an empty MAP: %w", err)
+	}
+	defer C.kuzu_value_destroy(zeroValue)
+	zeroMap, err := createKuzuMap([]*C.kuzu_value{zeroKey}, []*C.kuzu_value{zeroValue})
+	if err != nil {
+		return nil, err
+	}
+	defer C.kuzu_value_destroy(zeroMap)
+	var cMapType C.kuzu_logical_type
+	C.kuzu_value_get_data_type(zeroMap, &cMapType)
+	defer C.kuzu_data_type_destroy(&cMapType)
+	return C.kuzu_value_create_default(&cMapType), nil
+}
+
+// createKuzuMap creates a kuzu_value representing a MAP with the specified
+// non-empty keys and values.
+func createKuzuMap(keys []*C.kuzu_value, values []*C.kuzu_value) (*C.kuzu_value, error) {
 	var kuzuValue *C.kuzu_value
-	status := C.kuzu_value_create_map(numItems, &keys[0], &values[0], &kuzuValue)
+	status := C.kuzu_value_create_map(C.uint64_t(len(keys)), &keys[0], &values[0], &kuzuValue)
 	if status != C.KuzuSuccess {
-		return nil, fmt.Errorf("failed to create MAP value with status: %d. please make sure all the keys are of the same type and all the values are of the same type", status)
+		return nil, newError(ErrorKindConversion, "failed to create MAP value with status: %d. please make sure all the keys are of the same type and all the values are of the same type", status)
 	}
 	return kuzuValue, nil
 }
 
 // goSliceToKuzuList converts a slice of any to a kuzu_value representing a LIST.
-// It returns an error if the slice is empty or if the values in the slice are of
-// different types.
-func goSliceToKuzuList(slice []any) (*C.kuzu_value, error) {
-	numItems := C.uint64_t(len(slice))
-	if numItems == 0 {
-		return nil, fmt.Errorf("failed to create LIST value because the slice is empty")
+// Numbers of different types are converted to a common type first, e.g. an int32
+// and a float64 to two float64, and nil elements are NULLs of the type of the
+// other elements. It returns an error if the slice is empty or if
+// the values in the slice are of different types.
+func goSliceToKuzuList(slice []any, options ValueOptions) (*C.kuzu_value, error) {
+	if len(slice) == 0 {
+		return nil, newError(ErrorKindConversion, "failed to create LIST value because the slice is empty, use TypedList or a slice of a concrete type to bind an empty LIST")
 	}
-	values := make([]*C.kuzu_value, 0, len(slice))
-	for _, item := range slice {
-		value, error := goValueToKuzuValue(item)
+	slice, err := widenNumbers(slice)
+	if err != nil {
+		return nil, newError(ErrorKindConversion, "failed to convert value in the slice with error: %w", err)
+	}
+	values := make([]*C.kuzu_value, len(slice))
+	var elementType *C.kuzu_logical_type
+	for i, item := range slice {
+		if item == nil {
+			continue
+		}
+		value, error := goValueToKuzuValue(item, options)
 		if error != nil {
-			return nil, fmt.Errorf("failed to convert value in the slice with error: %w", error)
+			return nil, newError(ErrorKindConversion, "failed to convert value in the slice with error: %w", error)
 		}
-		values = append(values, value)
+		values[i] = value
 		defer C.kuzu_value_destroy(value)
+		if elementType == nil {
+			elementType = &C.kuzu_logical_type{}
+			C.kuzu_value_get_data_type(value, elementType)
+			defer C.kuzu_data_type_destroy(elementType)
+		}
+	}
+	// The NULL elements take the type of the other elements.
+	for i, value := range values {
+		if value != nil {
+			continue
+		}
+		if elementType == nil {
+			values[i] = C.kuzu_value_create_null()
+		} else {
+			values[i] = C.kuzu_value_create_null_with_data_type(elementType)
+		}
+		defer C.kuzu_value_destroy(values[i])
 	}
+	return createKuzuList(values)
+}
+
+// createKuzuList creates a kuzu_value representing a LIST with the specified
+// non-empty values.
+func createKuzuList(values []*C.kuzu_value) (*C.kuzu_value, error) {
 	var kuzuValue *C.kuzu_value
-	status := C.kuzu_value_create_list(numItems, &values[0], &kuzuValue)
+	status := C.kuzu_value_create_list(C.uint64_t(len(values)), &values[0], &kuzuValue)
 	if status != C.KuzuSuccess {
-		return nil, fmt.Errorf("failed to create LIST value with status: %d. please make sure all the values are of the same type", status)
+		return nil, newError(ErrorKindConversion, "failed to create LIST value with status: %d. please make sure all the values are of the same type", status)
 	}
 	return kuzuValue, nil
 }
 
-// kuzuValueToGoValue converts a Go value to a kuzu_value.
-func goValueToKuzuValue(value any) (*C.kuzu_value, error) {
+// goReflectSliceToKuzuList converts a Go slice or array to a kuzu_value
+// representing a LIST. The C API cannot create ARRAY values, so arrays are
+// bound as LISTs of the same length, which Kuzu casts implicitly when they
+// are compared to or stored in ARRAY values, or passed to ARRAY functions.
+// An empty slice is bound as an empty LIST of the type of its elements.
+func goReflectSliceToKuzuList(sliceValue reflect.Value, options ValueOptions) (*C.kuzu_value, error) {
+	if sliceValue.Len() == 0 {
+		elementType := sliceValue.Type().Elem()
+		if elementType.Kind() == reflect.Interface {
+			return nil, newError(ErrorKindConversion, "failed to create LIST value because the %s is empty, use TypedList to bind an empty LIST", sliceValue.Type())
+		}
+		return goEmptyListToKuzuValue(elementType, options)
+	}
+	slice := make([]any, sliceValue.Len())
+	for i := range slice {
+		slice[i] = sliceValue.Index(i).Interface()
+	}
+	return goSliceToKuzuList(slice, options)
+}
+
+// goEmptyListToKuzuValue creates an empty LIST of the Kuzu type that values of
+// the Go element type are bound as.
+func goEmptyListToKuzuValue(elementType reflect.Type, options ValueOptions) (*C.kuzu_value, error) {
+	zeroValue, err := goValueToKuzuValue(reflect.Zero(elementType).Interface(), options)
+	if err != nil {
+		return nil, newError(ErrorKindConversion, "failed to infer the type of the elements of an empty LIST: %w", err)
+	}
+	defer C.kuzu_value_destroy(zeroValue)
+	var cElementType, cListType C.kuzu_logical_type
+	C.kuzu_value_get_data_type(zeroValue, &cElementType)
+	defer C.kuzu_data_type_destroy(&cElementType)
+	C.kuzu_data_type_create(C.KUZU_LIST, &cElementType, 0, &cListType)
+	defer C.kuzu_data_type_destroy(&cListType)
+	return C.kuzu_value_create_default(&cListType), nil
+}
+
+// goTypedValueToKuzuValue converts a Go value to a kuzu_value of the specified
+// type, as described in TypedValue.
+func goTypedValueToKuzuValue(value any, logicalType LogicalType, options ValueOptions) (*C.kuzu_value, error) {
+	reflectValue := reflect.ValueOf(value)
+	for reflectValue.Kind() == reflect.Ptr && !reflectValue.IsNil() {
+		reflectValue = reflectValue.Elem()
+	}
+	if value == nil || reflectValue.Kind() == reflect.Ptr {
+		cLogicalType, err := logicalTypeToKuzu(logicalType)
+		if err != nil {
+			return nil, err
+		}
+		defer C.kuzu_data_type_destroy(&cLogicalType)
+		return C.kuzu_value_create_null_with_data_type(&cLogicalType), nil
+	}
+	value = reflectValue.Interface()
+	switch logicalType.ID {
+	case TypeList, TypeArray:
+		if reflectValue.Kind() == reflect.Slice || reflectValue.Kind() == reflect.Array {
+			return goTypedListToKuzuValue(reflectValue, logicalType, options)
+		}
+	case TypeMap:
+		if items, ok := value.([]MapItem); ok {
+			return goTypedMapToKuzuValue(items, logicalType, options)
+		}
+		if reflectValue.Kind() == reflect.Map {
+			items := make([]MapItem, 0, reflectValue.Len())
+			iter := reflectValue.MapRange()
+			for iter.Next() {
+				items = append(items, MapItem{Key: iter.Key().Interface(), Value: iter.Value().Interface()})
+			}
+			return goTypedMapToKuzuValue(items, logicalType, options)
+		}
+	case TypeUnion:
+		if union, ok := value.(Union); ok {
+			return goTypedUnionToKuzuValue(union, logicalType, options)
+		}
+	case TypeStruct:
+		if fields, ok := value.(map[string]any); ok {
+			if len(logicalType.FieldNames) == 0 || len(logicalType.FieldNames) != len(logicalType.Children) {
+				return nil, newError(ErrorKindConversion, "a STRUCT type must have a name for each of its one or more fields")
+			}
+			fieldValues := make([]any, len(logicalType.FieldNames))
+			for i, name := range logicalType.FieldNames {
+				fieldValues[i] = TypedValue{Type: logicalType.Children[i], Value: fields[name]}
+			}
+			kuzuValue, err := createKuzuStruct(logicalType.FieldNames, fieldValues, options)
+			if err != nil {
+				return nil, newError(ErrorKindConversion, "failed to convert field of the STRUCT with error: %w", err)
+			}
+			return kuzuValue, nil
+		}
+	default:
+		if goType, ok := scanTypes[logicalType.ID]; ok && isNumberKind(goType.Kind()) && isNumberKind(reflectValue.Kind()) {
+			converted := reflect.New(goType).Elem()
+			if err := assignBasic(converted, reflectValue); err != nil {
+				return nil, newError(ErrorKindConversion, "failed to convert %v to %s: %w", value, logicalType, err)
+			}
+			value = converted.Interface()
+		}
+	}
+	return goValueToKuzuValue(value, options)
+}
+
+// goTypedUnionToKuzuValue converts a Union to a kuzu_value of the type of the
+// member of the UNION type named by its Tag, which Kuzu converts to the first
+// member of that type. It returns an error if Tag names no member, if Value
+// cannot be converted to the type of the member, or if an earlier member has
+// the same type, since the value would be converted to that member instead. A
+// Union with an empty Tag is bound as its Value.
+func goTypedUnionToKuzuValue(union Union, logicalType LogicalType, options ValueOptions) (*C.kuzu_value, error) {
+	if union.Tag == "" {
+		return goValueToKuzuValue(union.Value, options)
+	}
+	if len(logicalType.FieldNames) == 0 || len(logicalType.FieldNames) != len(logicalType.Children) {
+		return nil, newError(ErrorKindConversion, "a UNION type must have a name for each of its one or more members")
+	}
+	member := slices.Index(logicalType.FieldNames, union.Tag)
+	if member < 0 {
+		return nil, newError(ErrorKindConversion, "%s has no member %q", logicalType, union.Tag)
+	}
+	kuzuValue, err := goTypedValueToKuzuValue(union.Value, logicalType.Children[member], options)
+	if err != nil {
+		return nil, err
+	}
+	var cLogicalType C.kuzu_logical_type
+	C.kuzu_value_get_data_type(kuzuValue, &cLogicalType)
+	valueTypeID := TypeID(C.kuzu_data_type_get_id(&cLogicalType))
+	C.kuzu_data_type_destroy(&cLogicalType)
+	selected := slices.IndexFunc(logicalType.Children, func(child LogicalType) bool {
+		return child.ID == valueTypeID
+	})
+	if selected != member {
+		C.kuzu_value_destroy(kuzuValue)
+		if selected < 0 {
+			return nil, newError(ErrorKindConversion, "failed to convert %T value to the type of the member %q of %s", union.Value, union.Tag, logicalType)
+		}
+		return nil, newError(ErrorKindConversion, "a %s value selects the member %q of %s rather than %q", valueTypeID, logicalType.FieldNames[selected], logicalType, union.Tag)
+	}
+	return kuzuValue, nil
+}
+
+// goTypedListToKuzuValue converts a Go slice or array to a kuzu_value of the
+// specified LIST or ARRAY type. Like other lists, an ARRAY is bound as a LIST
+// of the same length.
+func goTypedListToKuzuValue(sliceValue reflect.Value, logicalType LogicalType, options ValueOptions) (*C.kuzu_value, error) {
+	if len(logicalType.Children) != 1 {
+		return nil, newError(ErrorKindConversion, "a %s type must have exactly one child type", logicalType.ID)
+	}
+	if logicalType.ID == TypeArray && uint64(sliceValue.Len()) != logicalType.ArrayLength {
+		return nil, newError(ErrorKindConversion, "failed to convert a list of %d elements to %s", sliceValue.Len(), logicalType)
+	}
+	listType := LogicalType{ID: TypeList, Children: logicalType.Children}
+	if sliceValue.Len() == 0 {
+		cListType, err := logicalTypeToKuzu(listType)
+		if err != nil {
+			return nil, err
+		}
+		defer C.kuzu_data_type_destroy(&cListType)
+		return C.kuzu_value_create_default(&cListType), nil
+	}
+	values := make([]*C.kuzu_value, 0, sliceValue.Len())
+	for i := 0; i < sliceValue.Len(); i++ {
+		value, err := goTypedValueToKuzuValue(sliceValue.Index(i).Interface(), logicalType.Children[0], options)
+		if err != nil {
+			return nil, newError(ErrorKindConversion, "failed to convert element %d of the list with error: %w", i, err)
+		}
+		values = append(values, value)
+		defer C.kuzu_value_destroy(value)
+	}
+	return createKuzuList(values)
+}
+
+// goTypedMapToKuzuValue converts the items of a map to a kuzu_value of the
+// specified MAP type.
+func goTypedMapToKuzuValue(items []MapItem, logicalType LogicalType, options ValueOptions) (*C.kuzu_value, error) {
+	if len(logicalType.Children) != 2 {
+		return nil, newError(ErrorKindConversion, "a MAP type must have exactly two child types")
+	}
+	if len(items) == 0 {
+		cMapType, err := logicalTypeToKuzu(logicalType)
+		if err != nil {
+			return nil, err
+		}
+		defer C.kuzu_data_type_destroy(&cMapType)
+		return C.kuzu_value_create_default(&cMapType), nil
+	}
+	keys := make([]*C.kuzu_value, 0, len(items))
+	values := make([]*C.kuzu_value, 0, len(items))
+	for _, item := range items {
+		key, err := goTypedValueToKuzuValue(item.Key, logicalType.Children[0], options)
+		if err != nil {
+			return nil, newError(ErrorKindConversion, "failed to convert key of the map with error: %w", err)
+		}
+		keys = append(keys, key)
+		defer C.kuzu_value_destroy(key)
+		value, err := goTypedValueToKuzuValue(item.Value, logicalType.Children[1], options)
+		if err != nil {
+			return nil, newError(ErrorKindConversion, "failed to convert value of the map with error: %w", err)
+		}
+		values = append(values, value)
+		defer C.kuzu_value_destroy(value)
+	}
+	return createKuzuMap(keys, values)
+}
+
+// goValueToKuzuValue converts a Go value to a kuzu_value. A non-nil pointer is
+// converted to the value it points to, and a nil pointer to a NULL of the type
+// of that value.
+func goValueToKuzuValue(value any, options ValueOptions) (*C.kuzu_value, error) {
 	if value == nil {
 		return C.kuzu_value_create_null(), nil
 	}
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() == reflect.Ptr && reflectValue.IsNil() {
+		if _, ok := encoders.Load(reflectValue.Type()); !ok {
+			return goNullToKuzuValue(reflectValue.Type(), options), nil
+		}
+	}
+	if encoded, ok, err := encodeValue(value); ok {
+		if err != nil {
+			return nil, newError(ErrorKindConversion, "failed to encode %T value: %w", value, err)
+		}
+		if reflect.TypeOf(encoded) == reflect.TypeOf(value) {
+			return nil, newError(ErrorKindConversion, "the encoder of %T returned a value of the same type", value)
+		}
+		return goValueToKuzuValue(encoded, options)
+	}
 	var kuzuValue *C.kuzu_value
 	switch v := value.(type) {
 	case bool:
@@ -608,31 +1147,172 @@ func goValueToKuzuValue(value any) (*C.kuzu_value, error) {
 		kuzuValue = C.kuzu_value_create_float(C.float(v))
 	case string:
 		kuzuValue = C.kuzu_value_create_string(C.CString(v))
-	case time.Time:
-		if timeHasNanoseconds(v) {
-			kuzuValue = C.kuzu_value_create_timestamp_ns(timeToKuzuTimestampNs(v))
-		} else {
-			kuzuValue = C.kuzu_value_create_timestamp(timeToKuzuTimestamp(v))
+	case []byte:
+		return goBlobToKuzuValue(v)
+	case io.Reader:
+		// The reader is read to the end into memory, as the C API takes the
+		// whole value at once.
+		blob, err := io.ReadAll(v)
+		if err != nil {
+			return nil, newError(ErrorKindConversion, "failed to read BLOB parameter: %w", err)
 		}
+		return goBlobToKuzuValue(blob)
+	case time.Time:
+		return goTimeToKuzuValue(v, options.TimestampType)
+	case Date:
+		kuzuValue = goDateToKuzuValue(v)
+	case TimestampTZ:
+		return goTimeToKuzuValue(time.Time(v), TypeTimestampTz)
+	case TimestampNs:
+		return goTimeToKuzuValue(time.Time(v), TypeTimestampNs)
+	case TimestampMs:
+		return goTimeToKuzuValue(time.Time(v), TypeTimestampMs)
+	case TimestampSec:
+		return goTimeToKuzuValue(time.Time(v), TypeTimestampSec)
 	case time.Duration:
 		interval := durationToKuzuInterval(v)
 		kuzuValue = C.kuzu_value_create_interval(interval)
+	case Interval:
+		kuzuValue = C.kuzu_value_create_interval(v.toKuzuInterval())
+	case *big.Int:
+		return goBigIntToKuzuValue(v)
+	case big.Int:
+		return goBigIntToKuzuValue(&v)
+	case Int128:
+		return goValueToKuzuValue(v.Int, options)
+	case uuid.UUID:
+		kuzuValue = goUUIDToKuzuValue(v)
+	case decimal.Decimal:
+		return goDecimalToKuzuValue(v)
+	case Decimal:
+		return goTypedDecimalToKuzuValue(v)
 	case map[string]any:
-		return goMapToKuzuStruct(v)
+		return goMapToKuzuStruct(v, options)
 	case []MapItem:
-		return goSliceOfMapItemsToKuzuMap(v)
+		return goSliceOfMapItemsToKuzuMap(v, options)
 	case []any:
-		return goSliceToKuzuList(v)
+		return goSliceToKuzuList(v, options)
+	case TypedValue:
+		return goTypedValueToKuzuValue(v.Value, v.Type, options)
+	case Union:
+		if v.Tag != "" {
+			return nil, newError(ErrorKindConversion, "cannot check the tag %q of a Union without its type, wrap it in a TypedValue of its UNION type or leave the tag empty", v.Tag)
+		}
+		return goValueToKuzuValue(v.Value, options)
 	default:
-		if reflect.TypeOf(value).Kind() == reflect.Slice {
-			sliceValue := reflect.ValueOf(value)
-			slice := make([]any, sliceValue.Len())
-			for i := 0; i < sliceValue.Len(); i++ {
-				slice[i] = sliceValue.Index(i).Interface()
+		if encoded, ok, err := encodeFallbackValue(value); ok {
+			if err != nil {
+				return nil, newError(ErrorKindConversion, "failed to encode %T value: %w", value, err)
 			}
-			return goSliceToKuzuList(slice)
+			return goValueToKuzuValue(encoded, options)
+		}
+		switch reflectValue.Kind() {
+		case reflect.Ptr:
+			return goValueToKuzuValue(reflectValue.Elem().Interface(), options)
+		case reflect.Struct:
+			return goStructToKuzuStruct(reflectValue, options)
+		case reflect.Slice, reflect.Array:
+			return goReflectSliceToKuzuList(reflectValue, options)
+		case reflect.Map:
+			return goReflectMapToKuzuMap(reflectValue, options)
 		}
-		return nil, fmt.Errorf("unsupported type: %T", v)
+		return nil, newError(ErrorKindConversion, "unsupported type: %T", v)
 	}
 	return kuzuValue, nil
 }
+
+// isNumberKind returns true if the kind is an integer or a floating-point
+// kind.
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// widenNumbers converts the elements of a list to a common numeric type if
+// they are numbers of different types, so that Kuzu accepts them in the same
+// LIST. A float widens the list to float64, unless all the elements are
+// float32. Integers widen to the largest integer type among them, and a mix of
+// signed and unsigned integers to a signed type large enough for both, up to
+// int64. The list is returned unchanged if it contains values other than
+// numbers and NULLs.
+func widenNumbers(values []any) ([]any, error) {
+	var firstType reflect.Type
+	mixed := false
+	hasFloat, hasFloat64, hasInteger := false, false, false
+	signedBits, unsignedBits := 0, 0
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		valueType := reflect.TypeOf(value)
+		if !isNumberKind(valueType.Kind()) {
+			return values, nil
+		}
+		if firstType == nil {
+			firstType = valueType
+		} else if valueType != firstType {
+			mixed = true
+		}
+		bits := valueType.Bits()
+		switch valueType.Kind() {
+		case reflect.Float32, reflect.Float64:
+			hasFloat = true
+			hasFloat64 = hasFloat64 || bits == 64
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			hasInteger = true
+			signedBits = max(signedBits, bits)
+		default:
+			hasInteger = true
+			unsignedBits = max(unsignedBits, bits)
+		}
+	}
+	if !mixed {
+		return values, nil
+	}
+	var target reflect.Type
+	switch {
+	case hasFloat && (hasFloat64 || hasInteger):
+		target = reflect.TypeOf(float64(0))
+	case hasFloat:
+		target = reflect.TypeOf(float32(0))
+	case unsignedBits == 0:
+		target = signedIntegerTypes[signedBits]
+	case signedBits == 0:
+		target = unsignedIntegerTypes[unsignedBits]
+	default:
+		target = signedIntegerTypes[min(max(signedBits, unsignedBits*2), 64)]
+	}
+	widened := make([]any, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		converted := reflect.New(target).Elem()
+		if err := assignBasic(converted, reflect.ValueOf(value)); err != nil {
+			return nil, err
+		}
+		widened[i] = converted.Interface()
+	}
+	return widened, nil
+}
+
+// signedIntegerTypes are the signed integer types by number of bits.
+var signedIntegerTypes = map[int]reflect.Type{
+	8:  reflect.TypeOf(int8(0)),
+	16: reflect.TypeOf(int16(0)),
+	32: reflect.TypeOf(int32(0)),
+	64: reflect.TypeOf(int64(0)),
+}
+
+// unsignedIntegerTypes are the unsigned integer types by number of bits.
+var unsignedIntegerTypes = map[int]reflect.Type{
+	8:  reflect.TypeOf(uint8(0)),
+	16: reflect.TypeOf(uint16(0)),
+	32: reflect.TypeOf(uint32(0)),
+	64: reflect.TypeOf(uint64(0)),
+}
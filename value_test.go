@@ -1,6 +1,8 @@
 package kuzu
 
 import (
+	"bytes"
+	"math"
 	"math/big"
 	"testing"
 	"time"
@@ -183,6 +185,32 @@ func TestBlob(t *testing.T) {
 	res.Close()
 }
 
+func TestBlobWithZeroBytes(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("RETURN BLOB('\\\\xAA\\\\x00AB\\\\x5C\\\\x00')")
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	value, err := next.GetValue(0)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xAA, 0x00, 'A', 'B', '\\', 0x00}, value)
+	var buffer bytes.Buffer
+	err = next.Scan(&buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xAA, 0x00, 'A', 'B', '\\', 0x00}, buffer.Bytes())
+	res.Close()
+}
+
+func TestBlobProperty(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (m:movies) WHERE m.length = 126 RETURN m.content")
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	value, err := next.GetValue(0)
+	assert.Nil(t, err)
+	assert.Equal(t, append([]byte{0xAA, 0xAB}, append([]byte("interesting"), 0x0B)...), value)
+	res.Close()
+}
+
 func TestDate(t *testing.T) {
 	_, conn := SetupTestDatabase(t)
 	res, error := conn.Query("RETURN DATE('1985-01-01')")
@@ -304,7 +332,54 @@ func TestInterval(t *testing.T) {
 	assert.True(t, res.HasNext())
 	next, _ := res.Next()
 	value, _ := next.GetValue(0)
-	assert.Equal(t, time.Duration(3*24*time.Hour), value)
+	assert.Equal(t, Interval{Days: 3}, value)
+}
+
+func TestIntervalMonths(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("RETURN INTERVAL(\"1 year 2 months 3 days 4 hours 5 minutes 6.000007 seconds\");")
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	value, _ := next.GetValue(0)
+	micros := int64(4*time.Hour+5*time.Minute+6*time.Second)/1000 + 7
+	assert.Equal(t, Interval{Months: 14, Days: 3, Micros: micros}, value)
+	// A time.Duration counts a month as 30 days.
+	var duration time.Duration
+	assert.Nil(t, next.Scan(&duration))
+	assert.Equal(t, 423*24*time.Hour+time.Duration(micros)*time.Microsecond, duration)
+}
+
+func TestIntervalAddTo(t *testing.T) {
+	location := time.FixedZone("UTC-2", -2*60*60)
+	start := time.Date(2024, 1, 31, 10, 0, 0, 0, location)
+	assert.Equal(t, time.Date(2024, 2, 29, 10, 0, 0, 0, location), Interval{Months: 1}.AddTo(start))
+	assert.Equal(t, time.Date(2024, 3, 1, 13, 0, 0, 0, location), Interval{Months: 1, Days: 1, Micros: 3 * 3600 * 1000000}.AddTo(start))
+	assert.Equal(t, time.Date(2023, 12, 31, 10, 0, 0, 0, location), Interval{Months: -1}.AddTo(start))
+	assert.Equal(t, time.Date(2025, 2, 28, 10, 0, 0, 0, location), Interval{Months: 12}.AddTo(time.Date(2024, 2, 29, 10, 0, 0, 0, location)))
+	assert.Equal(t, time.Date(2024, 2, 29, 10, 0, 0, 0, location), Interval{Months: 1}.Negate().AddTo(time.Date(2024, 3, 31, 10, 0, 0, 0, location)))
+	assert.Equal(t, Interval{Micros: 1500}, IntervalFromDuration(1500*time.Microsecond))
+	// The microseconds longer than a time.Duration are added as well.
+	days := 400 * 365
+	micros := int64(days) * int64(24*time.Hour/time.Microsecond)
+	start = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, start.AddDate(0, 0, days), Interval{Micros: micros}.AddTo(start))
+	assert.Equal(t, start.AddDate(0, 0, -days), Interval{Micros: -micros}.AddTo(start))
+}
+
+func TestIntervalAsDuration(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	defer conn.Close()
+	conn.SetValueOptions(ValueOptions{IntervalAsDuration: true})
+	res, err := conn.Query("RETURN INTERVAL(\"1 day 2 hours\"), [INTERVAL(\"3 seconds\")];")
+	assert.Nil(t, err)
+	defer res.Close()
+	next, err := res.Next()
+	assert.Nil(t, err)
+	values, err := next.GetAsSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []any{26 * time.Hour, []any{3 * time.Second}}, values)
 }
 
 func TestList(t *testing.T) {
@@ -377,7 +452,68 @@ func TestUnion(t *testing.T) {
 	assert.True(t, res.HasNext())
 	next, _ := res.Next()
 	value, _ := next.GetValue(0)
-	assert.InDelta(t, float64(8.989), value.(map[string]any)["credit"], floatEpsilon)
+	assert.Equal(t, "grade1", value.(Union).Tag)
+	assert.InDelta(t, float64(8.989), value.(Union).Value, floatEpsilon)
+}
+
+func TestUnionSameTypeMembers(t *testing.T) {
+	db, err := OpenInMemoryDatabase(DefaultSystemConfig())
+	assert.Nil(t, err)
+	defer db.Close()
+	conn, err := OpenConnection(db)
+	assert.Nil(t, err)
+	defer conn.Close()
+	_, err = conn.Query("CREATE NODE TABLE item(id INT64, u UNION(a INT64, b INT64), PRIMARY KEY(id));")
+	assert.Nil(t, err)
+	_, err = conn.Query("CREATE (:item {id: 1, u: 2});")
+	assert.Nil(t, err)
+	res, err := conn.Query("MATCH (i:item) RETURN i.u, union_tag(i.u);")
+	assert.Nil(t, err)
+	defer res.Close()
+	next, err := res.Next()
+	assert.Nil(t, err)
+	values, err := next.GetAsSlice()
+	assert.Nil(t, err)
+	// The members of the same type are ambiguous: the first one is reported.
+	assert.Equal(t, Union{Tag: "a", Value: int64(2)}, values[0])
+	assert.Equal(t, "a", values[1])
+}
+
+func TestUnionTag(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	queries := []string{
+		"MATCH (m:movies) RETURN m.grade, union_tag(m.grade)",
+		"MATCH (o:organisation) RETURN o.info, union_tag(o.info)",
+		"MATCH (m:movies) RETURN [m.grade][1], union_tag(m.grade)",
+		"MATCH (m:movies) RETURN {g: m.grade}.g, union_tag(m.grade)",
+		"MATCH (m:movies) RETURN m, union_tag(m.grade)",
+		"MATCH ()-[k:knows]->() RETURN k, union_tag(k.notes)",
+	}
+	for _, query := range queries {
+		res, err := conn.Query(query)
+		assert.Nil(t, err)
+		for res.HasNext() {
+			next, _ := res.Next()
+			value, _ := next.GetValue(0)
+			tag, _ := next.GetValue(1)
+			switch v := value.(type) {
+			case Node:
+				value = v.Properties["grade"]
+			case Relationship:
+				value = v.Properties["notes"]
+			}
+			assert.Equal(t, tag, value.(Union).Tag, query)
+		}
+		res.Close()
+	}
+	res, err := conn.Query("MATCH (m:movies) WHERE union_tag(m.grade) = 'credit' RETURN [m.grade], {g: m.grade}")
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	values, err := next.GetAsSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []any{Union{Tag: "credit", Value: true}}, values[0])
+	assert.Equal(t, map[string]any{"g": Union{Tag: "credit", Value: true}}, values[1])
+	res.Close()
 }
 
 func TestNode(t *testing.T) {
@@ -407,8 +543,8 @@ func TestNode(t *testing.T) {
 	assert.Equal(t, 11, registerTime.Hour())
 	assert.Equal(t, 25, registerTime.Minute())
 	assert.Equal(t, 30, registerTime.Second())
-	lastJobDuration := node.Properties["lastJobDuration"].(time.Duration)
-	assert.Equal(t, 1082*24*time.Hour+46920*time.Second, lastJobDuration)
+	lastJobDuration := node.Properties["lastJobDuration"].(Interval)
+	assert.Equal(t, Interval{Months: 36, Days: 2, Micros: 46920 * 1000000}, lastJobDuration)
 	courseScoresPerTerm := node.Properties["courseScoresPerTerm"].([]interface{})
 	assert.Equal(t, 2, len(courseScoresPerTerm))
 	assert.Equal(t, []interface{}{int64(10), int64(8)}, courseScoresPerTerm[0].([]interface{}))
@@ -437,6 +573,74 @@ func TestRelationship(t *testing.T) {
 	assert.Equal(t, int64(2010), rel.Properties["year"])
 }
 
+func TestNodeDecode(t *testing.T) {
+	type person struct {
+		ID        InternalID `kuzu:"_id"`
+		Label     string     `kuzu:"_label"`
+		Key       int64      `kuzu:"ID"`
+		Name      string     `kuzu:"fName"`
+		Age       int
+		Birthdate time.Time
+		Grades    []int
+		UsedNames []string
+		EyeSight  *float64
+	}
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (a:person) WHERE a.ID = 0 RETURN a;")
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	value, _ := next.GetValue(0)
+	node := value.(Node)
+	var alice person
+	err = node.Decode(&alice)
+	assert.Nil(t, err)
+	assert.Equal(t, node.ID, alice.ID)
+	assert.Equal(t, "person", alice.Label)
+	assert.Equal(t, int64(0), alice.Key)
+	assert.Equal(t, "Alice", alice.Name)
+	assert.Equal(t, 35, alice.Age)
+	assert.Equal(t, 1900, alice.Birthdate.Year())
+	assert.Equal(t, []int{96, 54, 86, 92}, alice.Grades)
+	assert.Equal(t, []string{"Aida"}, alice.UsedNames)
+	assert.InDelta(t, 5.0, *alice.EyeSight, floatEpsilon)
+	err = node.Decode(alice)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "non-nil pointer to a struct")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	var scanned person
+	err = next.Scan(&scanned)
+	assert.Nil(t, err)
+	assert.Equal(t, alice, scanned)
+	next.Close()
+	res.Close()
+}
+
+func TestRelationshipDecode(t *testing.T) {
+	type workAt struct {
+		ID     InternalID `kuzu:"_id"`
+		Label  string     `kuzu:"_label"`
+		Source InternalID `kuzu:"_src"`
+		Target InternalID `kuzu:"_dst"`
+		Year   int
+	}
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("MATCH (p:person)-[r:workAt]->(o:organisation) WHERE p.ID = 5 RETURN r")
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	value, _ := next.GetValue(0)
+	rel := value.(Relationship)
+	var work workAt
+	err = rel.Decode(&work)
+	assert.Nil(t, err)
+	assert.Equal(t, rel.ID, work.ID)
+	assert.Equal(t, "workAt", work.Label)
+	assert.Equal(t, rel.SourceID, work.Source)
+	assert.Equal(t, rel.DestinationID, work.Target)
+	assert.Equal(t, 2010, work.Year)
+	next.Close()
+	res.Close()
+}
+
 func TestRecursiveRel(t *testing.T) {
 	_, conn := SetupTestDatabase(t)
 	res, error := conn.Query("MATCH (a:person)-[e:studyAt*1..1]->(b:organisation) WHERE a.fName = 'Alice' RETURN e;")
@@ -452,3 +656,115 @@ func TestRecursiveRel(t *testing.T) {
 	assert.Equal(t, int16(5), rel.Properties["length"])
 	assert.Equal(t, int64(2021), rel.Properties["year"])
 }
+
+func TestMapAsGoMap(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, _ := OpenConnection(db)
+	defer conn.Close()
+	assert.Equal(t, ValueOptions{}, conn.GetValueOptions())
+	conn.SetValueOptions(ValueOptions{GoMaps: true})
+	assert.Equal(t, ValueOptions{GoMaps: true}, conn.GetValueOptions())
+	queries := []string{
+		"MATCH (m:movies) WHERE m.length = 2544 RETURN m.audience",
+		"RETURN map([1, 2], ['One', 'Two'])",
+		"RETURN map(['a'], [map([date('2024-01-02')], [[1, NULL]])])",
+		"RETURN map(['a', 'b'], [[1], NULL])",
+		"RETURN map(['a', 'b'], [1, NULL])",
+		"RETURN map([CAST(1 AS INT128)], ['One'])",
+		"RETURN map(CAST([] AS INT64[]), CAST([] AS STRING[]))",
+	}
+	expected := []any{
+		map[string]int64{"audience1": 33},
+		map[int64]string{1: "One", 2: "Two"},
+		map[string]map[time.Time][]any{"a": {time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC): {int64(1), nil}}},
+		map[string][]any{"a": {int64(1)}, "b": nil},
+		[]MapItem{{Key: "a", Value: int64(1)}, {Key: "b", Value: nil}},
+		[]MapItem{{Key: big.NewInt(1), Value: "One"}},
+		[]MapItem{},
+	}
+	for i, query := range queries {
+		res, err := conn.Query(query)
+		assert.Nil(t, err)
+		next, _ := res.Next()
+		value, err := next.GetValue(0)
+		assert.Nil(t, err)
+		assert.Equal(t, expected[i], value, query)
+		res.Close()
+	}
+}
+
+func TestTimeOptions(t *testing.T) {
+	db, _ := SetupTestDatabase(t)
+	conn, _ := OpenConnection(db)
+	defer conn.Close()
+	query := "RETURN timestamp('2024-01-02 03:04:05'), CAST('2024-01-02 03:04:05+02:00' AS TIMESTAMP_TZ), date('2024-01-02'), CAST('1600-01-02 03:04:05' AS TIMESTAMP_SEC)"
+	location := time.FixedZone("UTC+10", 10*60*60)
+	conn.SetValueOptions(ValueOptions{Location: location})
+	res, err := conn.Query(query)
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	values, err := next.GetAsSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []any{
+		time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).In(location),
+		time.Date(2024, 1, 2, 1, 4, 5, 0, time.UTC).In(location),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, location),
+		time.Date(1600, 1, 2, 3, 4, 5, 0, time.UTC).In(location),
+	}, values)
+	res.Close()
+	conn.SetValueOptions(ValueOptions{Location: location, TimestampTZInUTC: true})
+	res, err = conn.Query(query)
+	assert.Nil(t, err)
+	next, _ = res.Next()
+	values, err = next.GetAsSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 1, 4, 5, 0, time.UTC), values[1])
+	assert.Equal(t, location, values[0].(time.Time).Location())
+	res.Close()
+}
+
+func TestIntervalDurationOverflow(t *testing.T) {
+	assert.Equal(t, time.Duration(math.MaxInt64), Interval{Months: 400 * 12}.Duration())
+	assert.Equal(t, time.Duration(math.MinInt64), Interval{Days: -200000, Micros: -1}.Duration())
+	assert.Equal(t, 200*24*time.Hour-time.Microsecond, Interval{Days: 200, Micros: -1}.Duration())
+	_, conn := SetupTestDatabase(t)
+	res, err := conn.Query("RETURN interval('400 years')")
+	assert.Nil(t, err)
+	next, _ := res.Next()
+	var duration time.Duration
+	assert.Nil(t, next.Scan(&duration))
+	assert.Equal(t, time.Duration(math.MaxInt64), duration)
+	res.Close()
+}
+
+func TestNulls(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	query := "RETURN CAST(NULL AS INT64) AS a, CAST(NULL AS STRING) AS b, CAST(NULL AS DOUBLE) AS c, CAST(NULL AS BOOL) AS d, CAST(NULL AS DATE) AS e, CAST(NULL AS TIMESTAMP) AS f, CAST(NULL AS BLOB) AS g, CAST(NULL AS UUID) AS h, CAST(NULL AS INTERVAL) AS i, CAST(NULL AS DECIMAL(5, 2)) AS j, CAST(NULL AS INT128) AS k, CAST(NULL AS INT64[]) AS l, CAST(NULL AS STRUCT(a INT64)) AS m, CAST(NULL AS MAP(STRING, INT64)) AS n;"
+	res, err := conn.Query(query)
+	assert.Nil(t, err)
+	defer res.Close()
+	next, err := res.Next()
+	assert.Nil(t, err)
+	values, err := next.GetAsSlice()
+	assert.Nil(t, err)
+	assert.Len(t, values, 14)
+	for i, value := range values {
+		assert.Nil(t, value, "column %d", i)
+	}
+}
+
+func TestNestedNulls(t *testing.T) {
+	_, conn := SetupTestDatabase(t)
+	query := "RETURN [1, NULL, 3] AS a, {x: NULL, y: 'b'} AS b, map(['x', 'y'], [1, NULL]) AS c, CAST([NULL, 1] AS INT64[2]) AS d;"
+	res, err := conn.Query(query)
+	assert.Nil(t, err)
+	defer res.Close()
+	next, err := res.Next()
+	assert.Nil(t, err)
+	values, err := next.GetAsSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []any{int64(1), nil, int64(3)}, values[0])
+	assert.Equal(t, map[string]any{"x": nil, "y": "b"}, values[1])
+	assert.Equal(t, []MapItem{{Key: "x", Value: int64(1)}, {Key: "y", Value: nil}}, values[2])
+	assert.Equal(t, []any{nil, int64(1)}, values[3])
+}